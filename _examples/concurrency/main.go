@@ -1,13 +1,11 @@
 package main
 
 import (
-	"context"
 	"log"
 	"math"
 
 	"github.com/c-bata/goptuna"
 	"github.com/c-bata/goptuna/tpe"
-	"golang.org/x/sync/errgroup"
 )
 
 func objective(trial goptuna.Trial) (float64, error) {
@@ -17,19 +15,17 @@ func objective(trial goptuna.Trial) (float64, error) {
 }
 
 func main() {
-	study, _ := goptuna.CreateStudy(
+	study, err := goptuna.CreateStudy(
 		"goptuna-example",
-		goptuna.StudyOptionSampler(tpe.NewSampler()),
+		goptuna.StudyOptionSampler(tpe.NewSampler(tpe.SamplerOptionConstantLiar(true))),
+		goptuna.StudyOptionNJobs(5),
 	)
-
-	eg, ctx := errgroup.WithContext(context.Background())
-	study.WithContext(ctx)
-	for i := 0; i < 5; i++ {
-		eg.Go(func() error {
-			return study.Optimize(objective, 20)
-		})
+	if err != nil {
+		log.Fatal("failed to create study:", err)
 	}
-	if err := eg.Wait(); err != nil {
+
+	// 5 goroutine workers evaluate 100 trials in total.
+	if err = study.Optimize(objective, 100); err != nil {
 		log.Fatal("Optimize error", err)
 	}
 
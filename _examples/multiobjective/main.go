@@ -0,0 +1,51 @@
+package main
+
+import (
+	"log"
+	"math"
+
+	"github.com/c-bata/goptuna"
+	"github.com/c-bata/goptuna/nsga2"
+)
+
+// Binh and Korn function.
+func objective(trial goptuna.Trial) ([]float64, error) {
+	x, _ := trial.SuggestFloat("x", 0, 5)
+	y, _ := trial.SuggestFloat("y", 0, 3)
+
+	v1 := 4*math.Pow(x, 2) + 4*math.Pow(y, 2)
+	v2 := math.Pow(x-5, 2) + math.Pow(y-5, 2)
+	return []float64{v1, v2}, nil
+}
+
+func main() {
+	study, err := goptuna.CreateStudy(
+		"goptuna-example",
+		goptuna.StudyOptionDirections([]goptuna.StudyDirection{
+			goptuna.StudyDirectionMinimize,
+			goptuna.StudyDirectionMinimize,
+		}),
+		goptuna.StudyOptionRelativeSampler(nsga2.NewSampler(
+			nsga2.SamplerOptionPopulationSize(20),
+			nsga2.SamplerOptionCrossover(nsga2.SBXCrossover{Eta: 15}),
+			nsga2.SamplerOptionMutation(nsga2.PolynomialMutation{Eta: 20}),
+		)),
+		goptuna.StudyOptionLogger(nil),
+	)
+	if err != nil {
+		log.Fatal("failed to create study:", err)
+	}
+
+	if err = study.OptimizeMulti(objective, 200); err != nil {
+		log.Fatal("failed to optimize:", err)
+	}
+
+	trials, err := study.GetBestTrials()
+	if err != nil {
+		log.Fatal("failed to get best trials:", err)
+	}
+	for _, trial := range trials {
+		log.Printf("Trial %d: values=%v (x=%f, y=%f)",
+			trial.Number, trial.Values, trial.Params["x"].(float64), trial.Params["y"].(float64))
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"sort"
 
 	"github.com/c-bata/goptuna"
+	"github.com/c-bata/goptuna/internal/numerical"
 	"gonum.org/v1/gonum/floats"
 	"gonum.org/v1/gonum/mat"
 )
@@ -27,6 +28,7 @@ type Sampler struct {
 	sigma0           float64
 	rng              *rand.Rand
 	nStartUpTrials   int
+	warmStart        bool
 	optimizerOptions []OptimizerOption
 	optimizer        *Optimizer
 	optimizerID      string
@@ -87,7 +89,13 @@ func (s *Sampler) SampleRelative(
 	err = nil
 
 	if s.optimizer == nil {
-		s.optimizer, err = s.initOptimizer(searchSpace, orderedKeys)
+		// The warm start mean is used only for the first optimizer,
+		// so that the restarts explore the other regions.
+		x0 := s.x0
+		if s.warmStart && x0 == nil {
+			x0 = warmStartMean(study.Direction(), completed, searchSpace)
+		}
+		s.optimizer, err = s.initOptimizer(searchSpace, orderedKeys, x0)
 		if err != nil {
 			return nil, err
 		}
@@ -127,7 +135,7 @@ func (s *Sampler) SampleRelative(
 
 			if s.optimizer.ShouldStop() && s.restartStrategy != "" {
 				popsize := s.nextPopsize()
-				s.optimizer, err = s.initOptimizer(searchSpace, orderedKeys,
+				s.optimizer, err = s.initOptimizer(searchSpace, orderedKeys, s.x0,
 					OptimizerOptionPopulationSize(popsize))
 				if err != nil {
 					return nil, err
@@ -192,14 +200,15 @@ func (s *Sampler) nextPopsize() (popsize int) {
 func (s *Sampler) initOptimizer(
 	searchSpace map[string]interface{},
 	orderedKeys []string,
+	mean0 map[string]float64,
 	additionalOpts ...OptimizerOption,
 ) (*Optimizer, error) {
 	x0, sigma0, err := s.initialParam(searchSpace)
 	if err != nil {
 		return nil, err
 	}
-	if s.x0 != nil {
-		x0 = s.x0
+	if mean0 != nil {
+		x0 = mean0
 	}
 	if s.sigma0 > 0 {
 		sigma0 = s.sigma0
@@ -213,7 +222,10 @@ func (s *Sampler) initOptimizer(
 		}
 		mean[i] = mean0
 	}
-	bounds := getSearchSpaceBounds(searchSpace, orderedKeys)
+	bounds, err := getSearchSpaceBounds(searchSpace, orderedKeys)
+	if err != nil {
+		return nil, err
+	}
 
 	options := make([]OptimizerOption, 0, 2+len(s.optimizerOptions)+len(additionalOpts))
 	options = append(options, OptimizerOptionBounds(bounds))
@@ -260,27 +272,93 @@ func supportedSearchSpace(searchSpace map[string]interface{}) map[string]interfa
 			normalized[name] = searchSpace[name]
 		case goptuna.StepIntUniformDistribution:
 			normalized[name] = searchSpace[name]
+		case goptuna.FloatDistribution:
+			normalized[name] = searchSpace[name]
+		case goptuna.IntDistribution:
+			normalized[name] = searchSpace[name]
 		}
 	}
 	return normalized
 }
 
+// warmStartMean returns the parameters of the best completed trial
+// which contains all parameters in the search space.
+func warmStartMean(
+	direction goptuna.StudyDirection,
+	completed []goptuna.FrozenTrial,
+	searchSpace map[string]interface{},
+) map[string]float64 {
+	var best *goptuna.FrozenTrial
+	for i := range completed {
+		contained := true
+		for name := range searchSpace {
+			if _, ok := completed[i].InternalParams[name]; !ok {
+				contained = false
+				break
+			}
+		}
+		if !contained {
+			continue
+		}
+		if best == nil ||
+			(direction == goptuna.StudyDirectionMinimize && completed[i].Value < best.Value) ||
+			(direction == goptuna.StudyDirectionMaximize && completed[i].Value > best.Value) {
+			best = &completed[i]
+		}
+	}
+	if best == nil {
+		return nil
+	}
+
+	x0 := make(map[string]float64, len(searchSpace))
+	for name := range searchSpace {
+		x0[name] = toCMAParam(searchSpace[name], best.InternalParams[name])
+	}
+	return x0
+}
+
 func toCMAParam(distribution interface{}, goptunaParam float64) float64 {
-	switch distribution.(type) {
+	switch d := distribution.(type) {
 	case goptuna.LogUniformDistribution:
 		return math.Log(goptunaParam)
+	case goptuna.FloatDistribution:
+		if d.Log {
+			return math.Log(goptunaParam)
+		}
+	case goptuna.IntDistribution:
+		if d.Log {
+			return math.Log(goptunaParam)
+		}
 	}
 	return goptunaParam
 }
 
 func toGoptunaInternalParam(distribution interface{}, cmaParam float64) float64 {
-	switch distribution.(type) {
+	switch d := distribution.(type) {
 	case goptuna.LogUniformDistribution:
 		return math.Exp(cmaParam)
+	case goptuna.FloatDistribution, goptuna.IntDistribution:
+		// The internal param is snapped onto the steps and clipped into the range.
+		n, _ := numerical.New(d)
+		if n.Log {
+			return n.Snap(math.Exp(cmaParam))
+		}
+		return n.Snap(cmaParam)
 	}
 	return cmaParam
 }
 
+// cmaBounds returns the bounds of the distribution in the CMA-ES search space.
+func cmaBounds(distribution interface{}) (float64, float64, error) {
+	switch d := distribution.(type) {
+	case goptuna.FloatDistribution:
+		return toCMAParam(d, d.Low), toCMAParam(d, d.High), nil
+	case goptuna.IntDistribution:
+		return toCMAParam(d, float64(d.Low)), toCMAParam(d, float64(d.High)), nil
+	}
+	return 0, 0, goptuna.ErrUnsupportedSearchSpace
+}
+
 func (s *Sampler) initialParam(searchSpace map[string]interface{}) (map[string]float64, float64, error) {
 	x0 := make(map[string]float64, len(searchSpace))
 	sigma0 := make([]float64, 0, len(searchSpace))
@@ -324,6 +402,17 @@ func (s *Sampler) initialParam(searchSpace map[string]interface{}) (map[string]f
 				x0[name] = float64(d.High+d.Low) / 2
 			}
 			sigma0 = append(sigma0, float64(d.High-d.Low)/6)
+		case goptuna.FloatDistribution, goptuna.IntDistribution:
+			low, high, err := cmaBounds(d)
+			if err != nil {
+				return nil, 0, err
+			}
+			if s.nRestarts > 0 {
+				x0[name] = low + s.rng.Float64()*(high-low)
+			} else {
+				x0[name] = (high + low) / 2
+			}
+			sigma0 = append(sigma0, (high-low)/6)
 		default:
 			return nil, 0, goptuna.ErrUnknownDistribution
 		}
@@ -334,7 +423,7 @@ func (s *Sampler) initialParam(searchSpace map[string]interface{}) (map[string]f
 func getSearchSpaceBounds(
 	searchSpace map[string]interface{},
 	orderedKeys []string,
-) *mat.Dense {
+) (*mat.Dense, error) {
 	bounds := mat.NewDense(len(orderedKeys), 2, nil)
 	for i, name := range orderedKeys {
 		switch d := searchSpace[name].(type) {
@@ -353,9 +442,16 @@ func getSearchSpaceBounds(
 		case goptuna.StepIntUniformDistribution:
 			bounds.Set(i, 0, float64(d.Low))
 			bounds.Set(i, 1, float64(d.High))
+		case goptuna.FloatDistribution, goptuna.IntDistribution:
+			low, high, err := cmaBounds(d)
+			if err != nil {
+				return nil, err
+			}
+			bounds.Set(i, 0, low)
+			bounds.Set(i, 1, high)
 		default:
-			panic("keys and search_space do not match")
+			return nil, goptuna.ErrUnsupportedSearchSpace
 		}
 	}
-	return bounds
+	return bounds, nil
 }
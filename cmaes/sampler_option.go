@@ -47,6 +47,16 @@ func SamplerOptionNStartupTrials(nStartupTrials int) SamplerOption {
 	}
 }
 
+// SamplerOptionWarmStart starts CMA-ES from the best completed trial
+// instead of the center of the search space. This is useful when the past results
+// are added to the study by Study.AddTrials. It is ignored if SamplerOptionInitialMean is set.
+// The restarts of IPOP-CMA-ES and BIPOP-CMA-ES don't start from the best trial.
+func SamplerOptionWarmStart(warmStart bool) SamplerOption {
+	return func(sampler *Sampler) {
+		sampler.warmStart = warmStart
+	}
+}
+
 // SamplerOptionIPop enables restart CMA-ES with increasing population size.
 // The argument is multiplier of population size before each restart and basically you should choose 2.
 // From the experiments in the IPOP-CMA-ES, it reveal similar performance for factors between 2 and 3.
@@ -0,0 +1,125 @@
+package cmaes
+
+import (
+	"math"
+	"testing"
+
+	"github.com/c-bata/goptuna"
+)
+
+func TestToGoptunaInternalParam(t *testing.T) {
+	tests := []struct {
+		name         string
+		distribution interface{}
+		cmaParam     float64
+		want         float64
+	}{
+		{
+			name:         "float",
+			distribution: goptuna.FloatDistribution{Low: 0, High: 1},
+			cmaParam:     0.33,
+			want:         0.33,
+		},
+		{
+			name:         "float with step",
+			distribution: goptuna.FloatDistribution{Low: 0, High: 1, Step: 0.25},
+			cmaParam:     0.33,
+			want:         0.25,
+		},
+		{
+			name:         "float out of range",
+			distribution: goptuna.FloatDistribution{Low: 0, High: 1},
+			cmaParam:     1.2,
+			want:         1,
+		},
+		{
+			name:         "log float",
+			distribution: goptuna.FloatDistribution{Low: 1e-3, High: 1, Log: true},
+			cmaParam:     math.Log(2),
+			want:         1,
+		},
+		{
+			name:         "int",
+			distribution: goptuna.IntDistribution{Low: 0, High: 10},
+			cmaParam:     3.6,
+			want:         4,
+		},
+		{
+			name:         "int with step",
+			distribution: goptuna.IntDistribution{Low: 0, High: 9, Step: 3},
+			cmaParam:     9.4,
+			want:         9,
+		},
+		{
+			name:         "log int",
+			distribution: goptuna.IntDistribution{Low: 1, High: 100, Log: true},
+			cmaParam:     math.Log(7.2),
+			want:         7,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := toGoptunaInternalParam(tt.distribution, tt.cmaParam)
+			if math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("toGoptunaInternalParam() = %f, want %f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCMABounds_UnsupportedDistribution(t *testing.T) {
+	_, _, err := cmaBounds(goptuna.CategoricalDistribution{Choices: []interface{}{"a", "b"}})
+	if err != goptuna.ErrUnsupportedSearchSpace {
+		t.Errorf("err: %v != ErrUnsupportedSearchSpace", err)
+	}
+}
+
+func TestSampler_WarmStartOnlyFirstOptimizer(t *testing.T) {
+	study, err := goptuna.CreateStudy("cmaes", goptuna.StudyOptionLogger(nil))
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	searchSpace := map[string]interface{}{
+		"x": goptuna.FloatDistribution{Low: -10, High: 10},
+		"y": goptuna.FloatDistribution{Low: -10, High: 10},
+	}
+	_, err = study.Storage.CloneTrial(study.ID, goptuna.FrozenTrial{
+		State:              goptuna.TrialStateComplete,
+		Value:              1,
+		Values:             []float64{1},
+		IntermediateValues: map[int]float64{},
+		InternalParams:     map[string]float64{"x": 4, "y": -3},
+		Params:             map[string]interface{}{"x": 4.0, "y": -3.0},
+		Distributions:      searchSpace,
+		UserAttrs:          map[string]string{},
+		SystemAttrs:        map[string]string{},
+	})
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	trialID, err := study.Storage.CreateNewTrial(study.ID)
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	trial, err := study.Storage.GetTrial(trialID)
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+
+	sampler := NewSampler(SamplerOptionWarmStart(true), SamplerOptionIPop(2))
+	if _, err = sampler.SampleRelative(study, trial, searchSpace); err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	if x, y := sampler.optimizer.mean.AtVec(0), sampler.optimizer.mean.AtVec(1); x != 4 || y != -3 {
+		t.Errorf("the first optimizer should start from (4, -3), but got (%f, %f)", x, y)
+	}
+	// Only the mean given by SamplerOptionInitialMean is used for the restarts.
+	if sampler.x0 != nil {
+		t.Errorf("the warm start mean should not be used for the restarts, but got %v", sampler.x0)
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/c-bata/goptuna/cmd/copystudy"
 	"github.com/c-bata/goptuna/cmd/createstudy"
 	"github.com/c-bata/goptuna/cmd/dashboard"
 	"github.com/c-bata/goptuna/cmd/deletestudy"
+	"github.com/c-bata/goptuna/cmd/trials"
 
 	"github.com/spf13/cobra"
 
@@ -27,6 +29,8 @@ var (
 func main() {
 	rootCmd.AddCommand(createstudy.GetCommand())
 	rootCmd.AddCommand(deletestudy.GetCommand())
+	rootCmd.AddCommand(copystudy.GetCommand())
+	rootCmd.AddCommand(trials.GetCommand())
 	rootCmd.AddCommand(dashboard.GetCommand())
 	if version != "" && revision != "" {
 		rootCmd.Version = fmt.Sprintf("%s (rev: %s)", version, revision)
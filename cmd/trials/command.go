@@ -0,0 +1,131 @@
+package trials
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/c-bata/goptuna"
+	"github.com/c-bata/goptuna/export"
+	"github.com/c-bata/goptuna/internal/sqlalchemy"
+	"github.com/c-bata/goptuna/rdb.v2"
+	"github.com/spf13/cobra"
+)
+
+var trialStates = map[string]goptuna.TrialState{
+	"running":  goptuna.TrialStateRunning,
+	"complete": goptuna.TrialStateComplete,
+	"pruned":   goptuna.TrialStatePruned,
+	"fail":     goptuna.TrialStateFail,
+	"waiting":  goptuna.TrialStateWaiting,
+}
+
+// GetCommand returns the cobra's command for trials sub-command.
+func GetCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:     "trials",
+		Short:   "Export the trials of a study as CSV or JSON Lines.",
+		Long:    "Export the trials of a study as CSV or JSON Lines.\nThe storage must be the relational database which is used by the rdb.v2 package.",
+		Example: "  goptuna trials --storage sqlite:///example.db --study study --format csv --states complete,pruned",
+		Run: func(cmd *cobra.Command, args []string) {
+			storageURL, err := cmd.Flags().GetString("storage")
+			if err != nil {
+				cmd.PrintErrln(err)
+				os.Exit(1)
+			}
+			if storageURL == "" {
+				cmd.PrintErrln("Storage URL is specified neither in config file nor --storage option.")
+				os.Exit(1)
+			}
+
+			studyName, err := cmd.Flags().GetString("study")
+			if err != nil {
+				cmd.PrintErrln(err)
+				os.Exit(1)
+			}
+
+			format, err := cmd.Flags().GetString("format")
+			if err != nil {
+				cmd.PrintErrln(err)
+				os.Exit(1)
+			}
+
+			var opts []export.Option
+			statesStr, err := cmd.Flags().GetString("states")
+			if err != nil {
+				cmd.PrintErrln(err)
+				os.Exit(1)
+			}
+			if statesStr != "" {
+				var states []goptuna.TrialState
+				for _, s := range strings.Split(statesStr, ",") {
+					state, ok := trialStates[strings.ToLower(strings.TrimSpace(s))]
+					if !ok {
+						cmd.PrintErrln(fmt.Sprintf("Unknown trial state: %s", s))
+						os.Exit(1)
+					}
+					states = append(states, state)
+				}
+				opts = append(opts, export.OptionStates(states...))
+			}
+			systemAttrs, err := cmd.Flags().GetBool("system-attrs")
+			if err != nil {
+				cmd.PrintErrln(err)
+				os.Exit(1)
+			}
+			opts = append(opts, export.OptionSystemAttrs(systemAttrs))
+			intermediateValues, err := cmd.Flags().GetBool("intermediate-values")
+			if err != nil {
+				cmd.PrintErrln(err)
+				os.Exit(1)
+			}
+			opts = append(opts, export.OptionIntermediateValues(intermediateValues))
+
+			db, err := sqlalchemy.GetGormDBFromURL(storageURL, nil)
+			if err != nil {
+				cmd.PrintErrln(err)
+				os.Exit(1)
+			}
+			storage := rdb.NewStorage(db)
+			studyID, err := storage.GetStudyIDFromName(studyName)
+			if err != nil {
+				cmd.PrintErrln(err)
+				os.Exit(1)
+			}
+			trials, err := storage.GetAllTrials(studyID)
+			if err != nil {
+				cmd.PrintErrln(err)
+				os.Exit(1)
+			}
+
+			w := bufio.NewWriter(os.Stdout)
+			err = export.Write(w, trials, export.Format(format), opts...)
+			if err == nil {
+				err = w.Flush()
+			}
+			if err != nil {
+				cmd.PrintErrln(err)
+				os.Exit(1)
+			}
+		},
+	}
+	command.Flags().StringP(
+		"storage", "", "", "DB URL of the rdb.v2 storage specified in Engine Database URL format of SQLAlchemy (e.g. sqlite:///example.db). See https://docs.sqlalchemy.org/en/13/core/engines.html for more details.")
+	command.Flags().StringP(
+		"study", "", "",
+		"A human-readable name of a study to distinguish it from others.")
+	command.Flags().StringP(
+		"format", "", "csv",
+		"Output format (csv or jsonl).")
+	command.Flags().StringP(
+		"states", "", "",
+		"Comma separated states of the exported trials (running, complete, pruned, fail or waiting). All trials are exported if not specified.")
+	command.Flags().BoolP(
+		"system-attrs", "", false,
+		"Export the system attributes of the trials.")
+	command.Flags().BoolP(
+		"intermediate-values", "", false,
+		"Export the intermediate values of the trials.")
+	return command
+}
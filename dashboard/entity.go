@@ -13,6 +13,7 @@ type TrialParam struct {
 	ParamInternalValue float64 `json:"param_internal_value"`
 	ParamExternalValue string  `json:"param_external_value"`
 	ParamExternalType  string  `json:"param_external_type"`
+	DistributionJSON   string  `json:"distribution_json"`
 }
 
 type TrialFixedParam struct {
@@ -80,12 +81,15 @@ func toIntermediateValues(from map[int]float64) []IntermediateValue {
 func toFrozenTrial(from goptuna.FrozenTrial) FrozenTrial {
 	params := make([]TrialParam, 0, len(from.Params))
 	for paramName := range from.Params {
+		// User-defined distributions might not be registered.
+		distributionJSON, _ := goptuna.DistributionToJSON(from.Distributions[paramName])
 		params = append(params, TrialParam{
 			Name:               paramName,
 			ParamInternalValue: from.InternalParams[paramName],
 			ParamExternalValue: fmt.Sprintf("%v", from.Params[paramName]),
 			// TODO(c-bata): Support this
 			ParamExternalType: "",
+			DistributionJSON:  string(distributionJSON),
 		})
 	}
 	sort.Slice(params, func(i, j int) bool {
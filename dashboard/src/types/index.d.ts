@@ -47,6 +47,7 @@ type TrialParam = {
   param_internal_value: number
   param_external_value: string
   param_external_type: string
+  distribution_json: string
   // distribution: Distribution
 }
 
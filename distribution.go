@@ -1,17 +1,29 @@
 package goptuna
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"math"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
 )
 
 var (
 	// ErrUnknownDistribution returns the distribution is unknown.
 	ErrUnknownDistribution = errors.New("unknown distribution")
+	// ErrIncompatibleDistribution returns the distribution is not compatible with
+	// the one of the same parameter name.
+	ErrIncompatibleDistribution = errors.New("incompatible distribution")
 )
 
 // Distribution represents a parameter that can be optimized.
+// You can define your own distribution by implementing this interface.
+// Please call RegisterDistribution to store it in the RDB storage.
 type Distribution interface {
 	// ToExternalRepr to convert internal representation of a parameter value into external representation.
 	ToExternalRepr(float64) interface{}
@@ -19,6 +31,9 @@ type Distribution interface {
 	Single() bool
 	// Contains to check a parameter value is contained in the range of this distribution.
 	Contains(float64) bool
+	// Sample draws internal representation of a parameter value at random.
+	// This is used by RandomSampler.
+	Sample(rng *rand.Rand) float64
 }
 
 var _ Distribution = &UniformDistribution{}
@@ -35,23 +50,31 @@ type UniformDistribution struct {
 const UniformDistributionName = "UniformDistribution"
 
 // ToExternalRepr to convert internal representation of a parameter value into external representation.
-func (d *UniformDistribution) ToExternalRepr(ir float64) interface{} {
+func (d UniformDistribution) ToExternalRepr(ir float64) interface{} {
 	return ir
 }
 
 // Single to test whether the range of this distribution contains just a single value.
-func (d *UniformDistribution) Single() bool {
+func (d UniformDistribution) Single() bool {
 	return d.High == d.Low
 }
 
 // Contains to check a parameter value is contained in the range of this distribution.
-func (d *UniformDistribution) Contains(ir float64) bool {
+func (d UniformDistribution) Contains(ir float64) bool {
 	if d.Single() {
 		return ir == d.Low
 	}
 	return d.Low <= ir && ir <= d.High
 }
 
+// Sample draws internal representation of a parameter value at random.
+func (d UniformDistribution) Sample(rng *rand.Rand) float64 {
+	if d.Single() {
+		return d.Low
+	}
+	return rng.Float64()*(d.High-d.Low) + d.Low
+}
+
 var _ Distribution = &LogUniformDistribution{}
 
 // LogUniformDistribution is a uniform distribution in the log domain.
@@ -66,23 +89,33 @@ type LogUniformDistribution struct {
 const LogUniformDistributionName = "LogUniformDistribution"
 
 // ToExternalRepr to convert internal representation of a parameter value into external representation.
-func (d *LogUniformDistribution) ToExternalRepr(ir float64) interface{} {
+func (d LogUniformDistribution) ToExternalRepr(ir float64) interface{} {
 	return ir
 }
 
 // Single to test whether the range of this distribution contains just a single value.
-func (d *LogUniformDistribution) Single() bool {
+func (d LogUniformDistribution) Single() bool {
 	return d.High == d.Low
 }
 
 // Contains to check a parameter value is contained in the range of this distribution.
-func (d *LogUniformDistribution) Contains(ir float64) bool {
+func (d LogUniformDistribution) Contains(ir float64) bool {
 	if d.Single() {
 		return ir == d.Low
 	}
 	return d.Low <= ir && ir <= d.High
 }
 
+// Sample draws internal representation of a parameter value at random.
+func (d LogUniformDistribution) Sample(rng *rand.Rand) float64 {
+	if d.Single() {
+		return d.Low
+	}
+	logLow := math.Log(d.Low)
+	logHigh := math.Log(d.High)
+	return math.Exp(rng.Float64()*(logHigh-logLow) + logLow)
+}
+
 var _ Distribution = &IntUniformDistribution{}
 
 // IntUniformDistribution is a uniform distribution on integers.
@@ -97,17 +130,17 @@ type IntUniformDistribution struct {
 const IntUniformDistributionName = "IntUniformDistribution"
 
 // ToExternalRepr to convert internal representation of a parameter value into external representation.
-func (d *IntUniformDistribution) ToExternalRepr(ir float64) interface{} {
+func (d IntUniformDistribution) ToExternalRepr(ir float64) interface{} {
 	return int(math.Round(ir))
 }
 
 // Single to test whether the range of this distribution contains just a single value.
-func (d *IntUniformDistribution) Single() bool {
+func (d IntUniformDistribution) Single() bool {
 	return d.High == d.Low
 }
 
 // Contains to check a parameter value is contained in the range of this distribution.
-func (d *IntUniformDistribution) Contains(ir float64) bool {
+func (d IntUniformDistribution) Contains(ir float64) bool {
 	value := d.ToExternalRepr(ir).(int)
 	if d.Single() {
 		return value == d.Low
@@ -115,6 +148,14 @@ func (d *IntUniformDistribution) Contains(ir float64) bool {
 	return d.Low <= value && value <= d.High
 }
 
+// Sample draws internal representation of a parameter value at random.
+func (d IntUniformDistribution) Sample(rng *rand.Rand) float64 {
+	if d.Single() {
+		return float64(d.Low)
+	}
+	return float64(rng.Intn(d.High-d.Low) + d.Low)
+}
+
 // StepIntUniformDistributionName is the identifier name of IntUniformDistribution
 const StepIntUniformDistributionName = "StepIntUniformDistribution"
 
@@ -129,14 +170,14 @@ type StepIntUniformDistribution struct {
 }
 
 // ToExternalRepr to convert internal representation of a parameter value into external representation.
-func (d *StepIntUniformDistribution) ToExternalRepr(ir float64) interface{} {
+func (d StepIntUniformDistribution) ToExternalRepr(ir float64) interface{} {
 	r := (ir - float64(d.Low)) / float64(d.Step) // shift to [0, (high-low)/step]
 	v := int(math.Round(r))*d.Step + d.Low
 	return v
 }
 
 // Single to test whether the range of this distribution contains just a single value.
-func (d *StepIntUniformDistribution) Single() bool {
+func (d StepIntUniformDistribution) Single() bool {
 	if d.High == d.Low {
 		return true
 	}
@@ -144,7 +185,7 @@ func (d *StepIntUniformDistribution) Single() bool {
 }
 
 // Contains to check a parameter value is contained in the range of this distribution.
-func (d *StepIntUniformDistribution) Contains(ir float64) bool {
+func (d StepIntUniformDistribution) Contains(ir float64) bool {
 	value := int(ir)
 	if d.Single() {
 		return value == d.Low
@@ -152,6 +193,16 @@ func (d *StepIntUniformDistribution) Contains(ir float64) bool {
 	return d.Low <= value && value < d.High
 }
 
+// Sample draws internal representation of a parameter value at random.
+func (d StepIntUniformDistribution) Sample(rng *rand.Rand) float64 {
+	if d.Single() {
+		return float64(d.Low)
+	}
+	r := (d.High - d.Low) / d.Step
+	v := (rng.Intn(r) * d.Step) + d.Low
+	return float64(v)
+}
+
 var _ Distribution = &DiscreteUniformDistribution{}
 
 // DiscreteUniformDistribution is a discretized uniform distribution in the linear domain.
@@ -168,17 +219,17 @@ type DiscreteUniformDistribution struct {
 const DiscreteUniformDistributionName = "DiscreteUniformDistribution"
 
 // ToExternalRepr to convert internal representation of a parameter value into external representation.
-func (d *DiscreteUniformDistribution) ToExternalRepr(ir float64) interface{} {
+func (d DiscreteUniformDistribution) ToExternalRepr(ir float64) interface{} {
 	return math.Floor((ir-d.Low)/d.Q+0.5)*d.Q + d.Low
 }
 
 // Single to test whether the range of this distribution contains just a single value.
-func (d *DiscreteUniformDistribution) Single() bool {
+func (d DiscreteUniformDistribution) Single() bool {
 	return d.High == d.Low
 }
 
 // Contains to check a parameter value is contained in the range of this distribution.
-func (d *DiscreteUniformDistribution) Contains(ir float64) bool {
+func (d DiscreteUniformDistribution) Contains(ir float64) bool {
 	if d.Single() {
 		return ir == d.Low
 	}
@@ -196,164 +247,542 @@ func (d *DiscreteUniformDistribution) Contains(ir float64) bool {
 	return false
 }
 
+// Sample draws internal representation of a parameter value at random.
+func (d DiscreteUniformDistribution) Sample(rng *rand.Rand) float64 {
+	if d.Single() {
+		return d.Low
+	}
+	q := d.Q
+	r := d.High - d.Low
+	// [low, high] is shifted to [0, r] to align sampled values at regular intervals.
+	low := 0 - 0.5*q
+	high := r + 0.5*q
+	x := rng.Float64()*(high-low) + low
+	v := math.Round(x/q)*q + d.Low
+	return math.Min(math.Max(v, d.Low), d.High)
+}
+
 var _ Distribution = &CategoricalDistribution{}
 
 // CategoricalDistribution is a distribution for categorical parameters
 type CategoricalDistribution struct {
-	// Choices is a candidates of parameter values
-	Choices []string `json:"choices"`
+	// Choices is a candidates of parameter values.
+	// Each choice must be nil, bool, int, float64 or string.
+	Choices []interface{} `json:"choices"`
 }
 
 // CategoricalDistributionName is the identifier name of CategoricalDistribution
 const CategoricalDistributionName = "CategoricalDistribution"
 
+// MarshalJSON encodes the choices like Optuna. Float choices are encoded
+// with a decimal point to distinguish them from int choices.
+func (d CategoricalDistribution) MarshalJSON() ([]byte, error) {
+	choices := make([]json.RawMessage, len(d.Choices))
+	for i := range d.Choices {
+		switch c := d.Choices[i].(type) {
+		case float64:
+			if math.IsNaN(c) || math.IsInf(c, 0) {
+				return nil, fmt.Errorf("unsupported categorical choice: %v", c)
+			}
+			s := strconv.FormatFloat(c, 'g', -1, 64)
+			if !strings.ContainsAny(s, ".eE") {
+				s += ".0"
+			}
+			choices[i] = json.RawMessage(s)
+		default:
+			if !isCategoricalChoice(c) {
+				return nil, fmt.Errorf("unsupported categorical choice: %#v", c)
+			}
+			b, err := json.Marshal(c)
+			if err != nil {
+				return nil, err
+			}
+			choices[i] = b
+		}
+	}
+	return json.Marshal(&struct {
+		Choices []json.RawMessage `json:"choices"`
+	}{
+		Choices: choices,
+	})
+}
+
+// UnmarshalJSON decodes the choices. Numbers with a decimal point or
+// an exponent are decoded as float64, and the others are decoded as int.
+func (d *CategoricalDistribution) UnmarshalJSON(data []byte) error {
+	var x struct {
+		Choices []interface{} `json:"choices"`
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&x); err != nil {
+		return err
+	}
+	for i := range x.Choices {
+		n, ok := x.Choices[i].(json.Number)
+		if !ok {
+			continue
+		}
+		if strings.ContainsAny(n.String(), ".eE") {
+			f, err := n.Float64()
+			if err != nil {
+				return err
+			}
+			x.Choices[i] = f
+		} else {
+			v, err := n.Int64()
+			if err != nil {
+				return err
+			}
+			x.Choices[i] = int(v)
+		}
+	}
+	d.Choices = x.Choices
+	return nil
+}
+
+func isCategoricalChoice(choice interface{}) bool {
+	switch choice.(type) {
+	case nil, bool, int, float64, string:
+		return true
+	}
+	return false
+}
+
 // ToExternalRepr to convert internal representation of a parameter value into external representation.
-func (d *CategoricalDistribution) ToExternalRepr(ir float64) interface{} {
+func (d CategoricalDistribution) ToExternalRepr(ir float64) interface{} {
 	return d.Choices[int(ir)]
 }
 
 // Single to test whether the range of this distribution contains just a single value.
-func (d *CategoricalDistribution) Single() bool {
+func (d CategoricalDistribution) Single() bool {
 	return len(d.Choices) == 1
 }
 
 // Contains to check a parameter value is contained in the range of this distribution.
-func (d *CategoricalDistribution) Contains(ir float64) bool {
+func (d CategoricalDistribution) Contains(ir float64) bool {
 	index := int(ir)
 	return 0 <= index && index < len(d.Choices)
 }
 
+// Sample draws internal representation of a parameter value at random.
+func (d CategoricalDistribution) Sample(rng *rand.Rand) float64 {
+	if d.Single() {
+		return float64(0)
+	}
+	return float64(rng.Intn(len(d.Choices)))
+}
+
+var _ Distribution = &FloatDistribution{}
+
+// FloatDistribution is a distribution for floating point parameters.
+// The parameter is sampled in the log domain if Log is true,
+// and discretized at regular intervals from Low if Step is larger than 0.
+type FloatDistribution struct {
+	// High is higher endpoint of the range of the distribution (included in the range).
+	High float64 `json:"high"`
+	// Low is lower endpoint of the range of the distribution (included in the range).
+	Low float64 `json:"low"`
+	// Step is a discretization step. Zero means that the parameter is continuous.
+	Step float64 `json:"step"`
+	// Log is whether the parameter is sampled in the log domain.
+	Log bool `json:"log"`
+}
+
+// FloatDistributionName is the identifier name of FloatDistribution
+const FloatDistributionName = "FloatDistribution"
+
+// MarshalJSON encodes the continuous distribution with null step like Optuna.
+func (d FloatDistribution) MarshalJSON() ([]byte, error) {
+	var step *float64
+	if d.Step > 0 {
+		step = &d.Step
+	}
+	return json.Marshal(&struct {
+		High float64  `json:"high"`
+		Low  float64  `json:"low"`
+		Step *float64 `json:"step"`
+		Log  bool     `json:"log"`
+	}{
+		High: d.High,
+		Low:  d.Low,
+		Step: step,
+		Log:  d.Log,
+	})
+}
+
+// ToExternalRepr to convert internal representation of a parameter value into external representation.
+func (d FloatDistribution) ToExternalRepr(ir float64) interface{} {
+	if d.Step <= 0 {
+		return ir
+	}
+	v := math.Round((ir-d.Low)/d.Step)*d.Step + d.Low
+	if v > d.High {
+		v -= d.Step
+	}
+	return math.Max(v, d.Low)
+}
+
+// Single to test whether the range of this distribution contains just a single value.
+func (d FloatDistribution) Single() bool {
+	if d.High == d.Low {
+		return true
+	}
+	return d.Step > 0 && d.High-d.Low < d.Step
+}
+
+// Contains to check a parameter value is contained in the range of this distribution.
+func (d FloatDistribution) Contains(ir float64) bool {
+	if d.Single() {
+		return ir == d.Low
+	}
+	if d.Low > ir || ir > d.High {
+		return false
+	}
+	if d.Step <= 0 {
+		return true
+	}
+
+	// Allow the rounding error of the values which are computed from Step.
+	eps := 1e-8
+	k := (ir - d.Low) / d.Step
+	return math.Abs(k-math.Round(k)) <= eps
+}
+
+// Sample draws internal representation of a parameter value at random.
+func (d FloatDistribution) Sample(rng *rand.Rand) float64 {
+	if d.Single() {
+		return d.Low
+	}
+	low, high := d.Low, d.High
+	if d.Step > 0 && !d.Log {
+		// Extend the range to sample 'low' and 'high' at the same probability.
+		low -= 0.5 * d.Step
+		high += 0.5 * d.Step
+	}
+	var x float64
+	if d.Log {
+		logLow := math.Log(low)
+		logHigh := math.Log(high)
+		x = math.Exp(rng.Float64()*(logHigh-logLow) + logLow)
+	} else {
+		x = rng.Float64()*(high-low) + low
+	}
+	return d.ToExternalRepr(x).(float64)
+}
+
+var _ Distribution = &IntDistribution{}
+
+// IntDistribution is a distribution for integer parameters.
+// The parameter is sampled in the log domain if Log is true,
+// and takes the values at intervals of Step from Low.
+type IntDistribution struct {
+	// High is higher endpoint of the range of the distribution (included in the range).
+	High int `json:"high"`
+	// Low is lower endpoint of the range of the distribution (included in the range).
+	Low int `json:"low"`
+	// Step is a spacing between values. Zero is regarded as 1.
+	Step int `json:"step"`
+	// Log is whether the parameter is sampled in the log domain.
+	Log bool `json:"log"`
+}
+
+// IntDistributionName is the identifier name of IntDistribution
+const IntDistributionName = "IntDistribution"
+
+func (d IntDistribution) step() int {
+	if d.Step <= 0 {
+		return 1
+	}
+	return d.Step
+}
+
+// MarshalJSON encodes the distribution with the step of 1 if Step is zero,
+// because Optuna doesn't accept the zero step.
+func (d IntDistribution) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		High int  `json:"high"`
+		Low  int  `json:"low"`
+		Step int  `json:"step"`
+		Log  bool `json:"log"`
+	}{
+		High: d.High,
+		Low:  d.Low,
+		Step: d.step(),
+		Log:  d.Log,
+	})
+}
+
+// ToExternalRepr to convert internal representation of a parameter value into external representation.
+func (d IntDistribution) ToExternalRepr(ir float64) interface{} {
+	step := d.step()
+	v := int(math.Round((ir-float64(d.Low))/float64(step)))*step + d.Low
+	if v > d.High {
+		v = d.High - (d.High-d.Low)%step
+	}
+	if v < d.Low {
+		v = d.Low
+	}
+	return v
+}
+
+// Single to test whether the range of this distribution contains just a single value.
+func (d IntDistribution) Single() bool {
+	if d.High == d.Low {
+		return true
+	}
+	return d.High-d.Low < d.step()
+}
+
+// Contains to check a parameter value is contained in the range of this distribution.
+func (d IntDistribution) Contains(ir float64) bool {
+	value := int(math.Round(ir))
+	if d.Single() {
+		return value == d.Low
+	}
+	return d.Low <= value && value <= d.High && (value-d.Low)%d.step() == 0
+}
+
+// Sample draws internal representation of a parameter value at random.
+func (d IntDistribution) Sample(rng *rand.Rand) float64 {
+	if d.Single() {
+		return float64(d.Low)
+	}
+	var x float64
+	if d.Log {
+		logLow := math.Log(float64(d.Low) - 0.5)
+		logHigh := math.Log(float64(d.High) + 0.5)
+		x = math.Exp(rng.Float64()*(logHigh-logLow) + logLow)
+	} else {
+		r := (d.High - d.Low) / d.step()
+		x = float64(rng.Intn(r+1)*d.step() + d.Low)
+	}
+	return float64(d.ToExternalRepr(x).(int))
+}
+
 // ToExternalRepresentation converts to external representation
 func ToExternalRepresentation(distribution interface{}, ir float64) (interface{}, error) {
+	d, ok := distribution.(Distribution)
+	if !ok {
+		return nil, ErrUnknownDistribution
+	}
+	return d.ToExternalRepr(ir), nil
+}
+
+// toInternalRepr converts the external representation of the parameter to
+// the internal representation of the distribution.
+func toInternalRepr(distribution interface{}, value interface{}) (float64, error) {
 	switch d := distribution.(type) {
-	case UniformDistribution:
-		return d.ToExternalRepr(ir), nil
-	case LogUniformDistribution:
-		return d.ToExternalRepr(ir), nil
-	case IntUniformDistribution:
-		return d.ToExternalRepr(ir), nil
-	case StepIntUniformDistribution:
-		return d.ToExternalRepr(ir), nil
-	case DiscreteUniformDistribution:
-		return d.ToExternalRepr(ir), nil
 	case CategoricalDistribution:
-		return d.ToExternalRepr(ir), nil
-	default:
-		return nil, ErrUnknownDistribution
+		for i := range d.Choices {
+			if d.Choices[i] == value {
+				return float64(i), nil
+			}
+		}
+		return 0, fmt.Errorf("%#v is not in choices %v", value, d.Choices)
+	case IntUniformDistribution, StepIntUniformDistribution, IntDistribution:
+		if v, ok := value.(int); ok {
+			return float64(v), nil
+		}
+		return 0, fmt.Errorf("%#v is not int", value)
+	}
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case int:
+		return float64(v), nil
+	}
+	return 0, fmt.Errorf("%#v is not float64", value)
+}
+
+// jsonValueToInternalRepr converts the external representation decoded by
+// json.Decoder with UseNumber to the internal representation of the distribution.
+func jsonValueToInternalRepr(distribution interface{}, value interface{}) (float64, error) {
+	n, ok := value.(json.Number)
+	if !ok {
+		return toInternalRepr(distribution, value)
+	}
+	switch d := distribution.(type) {
+	case CategoricalDistribution:
+		for i := range d.Choices {
+			switch c := d.Choices[i].(type) {
+			case int:
+				if v, err := n.Int64(); err == nil && int(v) == c {
+					return float64(i), nil
+				}
+			case float64:
+				if v, err := n.Float64(); err == nil && v == c {
+					return float64(i), nil
+				}
+			}
+		}
+		return 0, fmt.Errorf("%s is not in choices %v", n, d.Choices)
+	case IntUniformDistribution, StepIntUniformDistribution, IntDistribution:
+		v, err := n.Int64()
+		if err != nil {
+			return 0, fmt.Errorf("%s is not int", n)
+		}
+		return float64(v), nil
 	}
+	return n.Float64()
 }
 
 // DistributionIsSingle whether the distribution contains just a single value.
 func DistributionIsSingle(distribution interface{}) (bool, error) {
+	d, ok := distribution.(Distribution)
+	if !ok {
+		return false, ErrUnknownDistribution
+	}
+	return d.Single(), nil
+}
+
+// distributionSpace is a normalized form of the distribution to check the compatibility.
+type distributionSpace struct {
+	kind    string
+	log     bool
+	low     float64
+	high    float64
+	choices []interface{}
+}
+
+func toDistributionSpace(distribution interface{}) distributionSpace {
 	switch d := distribution.(type) {
 	case UniformDistribution:
-		return d.Single(), nil
+		return distributionSpace{kind: "float", low: d.Low, high: d.High}
 	case LogUniformDistribution:
-		return d.Single(), nil
+		return distributionSpace{kind: "float", log: true, low: d.Low, high: d.High}
+	case DiscreteUniformDistribution:
+		return distributionSpace{kind: "float", low: d.Low, high: d.High}
+	case FloatDistribution:
+		return distributionSpace{kind: "float", log: d.Log, low: d.Low, high: d.High}
 	case IntUniformDistribution:
-		return d.Single(), nil
+		return distributionSpace{kind: "int", low: float64(d.Low), high: float64(d.High)}
 	case StepIntUniformDistribution:
-		return d.Single(), nil
-	case DiscreteUniformDistribution:
-		return d.Single(), nil
+		return distributionSpace{kind: "int", low: float64(d.Low), high: float64(d.High)}
+	case IntDistribution:
+		return distributionSpace{kind: "int", log: d.Log, low: float64(d.Low), high: float64(d.High)}
 	case CategoricalDistribution:
-		return d.Single(), nil
-	default:
-		return false, ErrUnknownDistribution
+		return distributionSpace{kind: "categorical", choices: d.Choices}
+	}
+	// User-defined distributions are compatible only with the same type.
+	return distributionSpace{kind: reflect.TypeOf(distribution).String()}
+}
+
+// CheckDistributionCompatibility checks whether the two distributions of the same
+// parameter name can be used in the same study. Distributions are compatible if both
+// are float (UniformDistribution, LogUniformDistribution, DiscreteUniformDistribution
+// and FloatDistribution) or int (IntUniformDistribution, StepIntUniformDistribution and
+// IntDistribution) with the same log scale, or categorical with the same choices.
+// It returns ErrIncompatibleDistribution if they are not compatible.
+// Compatible distributions may have different ranges.
+func CheckDistributionCompatibility(old, new interface{}) error {
+	o := toDistributionSpace(old)
+	n := toDistributionSpace(new)
+	if o.kind != n.kind {
+		return fmt.Errorf("%w: %s and %s", ErrIncompatibleDistribution, o.kind, n.kind)
+	}
+	if o.log != n.log {
+		return fmt.Errorf("%w: log=%t and log=%t", ErrIncompatibleDistribution, o.log, n.log)
+	}
+	if o.kind == "categorical" && !reflect.DeepEqual(o.choices, n.choices) {
+		return fmt.Errorf("%w: choices %v and %v", ErrIncompatibleDistribution, o.choices, n.choices)
 	}
+	return nil
 }
 
-// DistributionToJSON serialize a distribution to JSON format.
+// distributionRangeIsChanged returns true if the compatible distributions
+// have the different ranges.
+func distributionRangeIsChanged(old, new interface{}) bool {
+	o := toDistributionSpace(old)
+	n := toDistributionSpace(new)
+	return o.low != n.low || o.high != n.high
+}
+
+var (
+	distributionRegistryMu sync.RWMutex
+	distributionTypes      = make(map[string]reflect.Type, 8)
+	distributionNames      = make(map[reflect.Type]string, 8)
+)
+
+func init() {
+	RegisterDistribution(UniformDistributionName, UniformDistribution{})
+	RegisterDistribution(LogUniformDistributionName, LogUniformDistribution{})
+	RegisterDistribution(IntUniformDistributionName, IntUniformDistribution{})
+	RegisterDistribution(StepIntUniformDistributionName, StepIntUniformDistribution{})
+	RegisterDistribution(DiscreteUniformDistributionName, DiscreteUniformDistribution{})
+	RegisterDistribution(CategoricalDistributionName, CategoricalDistribution{})
+	RegisterDistribution(FloatDistributionName, FloatDistribution{})
+	RegisterDistribution(IntDistributionName, IntDistribution{})
+}
+
+// RegisterDistribution makes the distribution available in DistributionToJSON
+// and JSONToDistribution under the given name. The distribution is encoded by
+// encoding/json, and decoded as the same type with the given value.
+// Like database/sql.Register, this is supposed to be called in init function,
+// and panics if the name or the type is already registered.
+func RegisterDistribution(name string, distribution Distribution) {
+	if distribution == nil {
+		panic("goptuna: RegisterDistribution distribution is nil")
+	}
+	t := reflect.TypeOf(distribution)
+
+	distributionRegistryMu.Lock()
+	defer distributionRegistryMu.Unlock()
+	if _, dup := distributionTypes[name]; dup {
+		panic("goptuna: RegisterDistribution called twice for name " + name)
+	}
+	if _, dup := distributionNames[t]; dup {
+		panic("goptuna: RegisterDistribution called twice for type " + t.String())
+	}
+	distributionTypes[name] = t
+	distributionNames[t] = name
+}
+
+// DistributionToJSON serialize a distribution to JSON format which is compatible with Optuna,
+// e.g. {"name":"IntDistribution","attributes":{"high":10,"low":1,"step":1,"log":false}}.
 func DistributionToJSON(distribution interface{}) ([]byte, error) {
+	distributionRegistryMu.RLock()
+	name, ok := distributionNames[reflect.TypeOf(distribution)]
+	distributionRegistryMu.RUnlock()
+	if !ok {
+		return nil, ErrUnknownDistribution
+	}
+
 	var ir struct {
 		Name  string      `json:"name"`
 		Attrs interface{} `json:"attributes"`
 	}
-	switch distribution.(type) {
-	case UniformDistribution:
-		ir.Name = UniformDistributionName
-	case LogUniformDistribution:
-		ir.Name = LogUniformDistributionName
-	case IntUniformDistribution:
-		ir.Name = IntUniformDistributionName
-	case DiscreteUniformDistribution:
-		ir.Name = DiscreteUniformDistributionName
-	case StepIntUniformDistribution:
-		ir.Name = StepIntUniformDistributionName
-	case CategoricalDistribution:
-		ir.Name = CategoricalDistributionName
-	default:
-		return nil, ErrUnknownDistribution
-	}
+	ir.Name = name
 	ir.Attrs = distribution
 	return json.Marshal(&ir)
 }
 
 // JSONToDistribution deserialize a distribution in JSON format.
+// The returned value has the same type with the one passed to RegisterDistribution.
+// It returns ErrUnknownDistribution if the name is not registered.
 func JSONToDistribution(jsonBytes []byte) (interface{}, error) {
 	var x struct {
-		Name  string      `json:"name"`
-		Attrs interface{} `json:"attributes"`
+		Name  string          `json:"name"`
+		Attrs json.RawMessage `json:"attributes"`
 	}
 	err := json.Unmarshal(jsonBytes, &x)
 	if err != nil {
 		return nil, err
 	}
-	switch x.Name {
-	case UniformDistributionName:
-		var y UniformDistribution
-		var dbytes []byte
-		dbytes, err = json.Marshal(x.Attrs)
-		if err != nil {
-			return nil, err
-		}
-		err = json.Unmarshal(dbytes, &y)
-		return y, err
-	case LogUniformDistributionName:
-		var y LogUniformDistribution
-		var dbytes []byte
-		dbytes, err = json.Marshal(x.Attrs)
-		if err != nil {
-			return nil, err
-		}
-		err = json.Unmarshal(dbytes, &y)
-		return y, err
-	case IntUniformDistributionName:
-		var y IntUniformDistribution
-		var dbytes []byte
-		dbytes, err = json.Marshal(x.Attrs)
-		if err != nil {
-			return nil, err
-		}
-		err = json.Unmarshal(dbytes, &y)
-		return y, err
-	case DiscreteUniformDistributionName:
-		var y DiscreteUniformDistribution
-		var dbytes []byte
-		dbytes, err = json.Marshal(x.Attrs)
-		if err != nil {
-			return nil, err
-		}
-		err = json.Unmarshal(dbytes, &y)
-		return y, err
-	case StepIntUniformDistributionName:
-		var y StepIntUniformDistribution
-		var dbytes []byte
-		dbytes, err = json.Marshal(x.Attrs)
-		if err != nil {
-			return nil, err
-		}
-		err = json.Unmarshal(dbytes, &y)
-		return y, err
-	case CategoricalDistributionName:
-		var y CategoricalDistribution
-		var dbytes []byte
-		dbytes, err = json.Marshal(x.Attrs)
-		if err != nil {
-			return nil, err
-		}
-		err = json.Unmarshal(dbytes, &y)
-		return y, err
+
+	distributionRegistryMu.RLock()
+	t, ok := distributionTypes[x.Name]
+	distributionRegistryMu.RUnlock()
+	if !ok {
+		return nil, ErrUnknownDistribution
+	}
+
+	if t.Kind() == reflect.Ptr {
+		y := reflect.New(t.Elem())
+		err = json.Unmarshal(x.Attrs, y.Interface())
+		return y.Interface(), err
 	}
-	return nil, ErrUnknownDistribution
+	y := reflect.New(t)
+	err = json.Unmarshal(x.Attrs, y.Interface())
+	return y.Elem().Interface(), err
 }
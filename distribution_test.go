@@ -1,6 +1,10 @@
 package goptuna_test
 
 import (
+	"bytes"
+	"errors"
+	"math"
+	"math/rand"
 	"reflect"
 	"testing"
 
@@ -52,7 +56,38 @@ func TestDistributionConversionBetweenDistributionAndJSON(t *testing.T) {
 		{
 			name: "categorical distribution",
 			distribution: goptuna.CategoricalDistribution{
-				Choices: []string{"foo", "bar"},
+				Choices: []interface{}{"foo", "bar"},
+			},
+		},
+		{
+			name: "categorical distribution with typed choices",
+			distribution: goptuna.CategoricalDistribution{
+				Choices: []interface{}{nil, true, 1, 1.0, 2.5, 1e-10, "foo"},
+			},
+		},
+		{
+			name: "float distribution",
+			distribution: goptuna.FloatDistribution{
+				High: 1e2,
+				Low:  1e-1,
+				Log:  true,
+			},
+		},
+		{
+			name: "float distribution with step",
+			distribution: goptuna.FloatDistribution{
+				High: 10,
+				Low:  -5,
+				Step: 0.5,
+			},
+		},
+		{
+			name: "int distribution",
+			distribution: goptuna.IntDistribution{
+				High: 1024,
+				Low:  1,
+				Step: 1,
+				Log:  true,
 			},
 		},
 	}
@@ -73,6 +108,222 @@ func TestDistributionConversionBetweenDistributionAndJSON(t *testing.T) {
 	}
 }
 
+func randomDistribution(rng *rand.Rand) interface{} {
+	low := rng.NormFloat64() * 100
+	high := low + rng.ExpFloat64()*100
+	intLow := rng.Intn(200) - 100
+	intHigh := intLow + rng.Intn(100)
+	switch rng.Intn(8) {
+	case 0:
+		return goptuna.UniformDistribution{High: high, Low: low}
+	case 1:
+		return goptuna.LogUniformDistribution{High: math.Abs(high) + 1, Low: math.Abs(low) + 1e-3}
+	case 2:
+		return goptuna.IntUniformDistribution{High: intHigh, Low: intLow}
+	case 3:
+		return goptuna.StepIntUniformDistribution{High: intHigh, Low: intLow, Step: rng.Intn(5) + 1}
+	case 4:
+		return goptuna.DiscreteUniformDistribution{High: high, Low: low, Q: rng.ExpFloat64()}
+	case 5:
+		if rng.Intn(2) == 0 {
+			return goptuna.FloatDistribution{High: math.Abs(high) + 1, Low: math.Abs(low) + 1e-3, Log: true}
+		}
+		return goptuna.FloatDistribution{High: high, Low: low, Step: float64(rng.Intn(3)) * rng.ExpFloat64()}
+	case 6:
+		if rng.Intn(2) == 0 {
+			return goptuna.IntDistribution{High: intHigh + 101, Low: intLow + 101, Step: 1, Log: true}
+		}
+		return goptuna.IntDistribution{High: intHigh, Low: intLow, Step: rng.Intn(5) + 1}
+	}
+	candidates := []interface{}{nil, true, false, rng.Intn(1000) - 500, rng.NormFloat64(), 1.0, 1e-8, "foo", "日本語"}
+	choices := make([]interface{}, 0, len(candidates))
+	for _, c := range candidates {
+		if rng.Intn(2) == 0 {
+			choices = append(choices, c)
+		}
+	}
+	return goptuna.CategoricalDistribution{Choices: choices}
+}
+
+func TestDistributionJSONRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	for i := 0; i < 1000; i++ {
+		distribution := randomDistribution(rng)
+		encoded, err := goptuna.DistributionToJSON(distribution)
+		if err != nil {
+			t.Errorf("DistributionToJSON(%#v) should not return err, but got %s", distribution, err)
+			return
+		}
+		decoded, err := goptuna.JSONToDistribution(encoded)
+		if err != nil {
+			t.Errorf("JSONToDistribution(%s) should not return err, but got %s", encoded, err)
+			return
+		}
+		if !reflect.DeepEqual(decoded, distribution) {
+			t.Errorf("round trip is not identical: %#v != %#v (json=%s)", decoded, distribution, encoded)
+			return
+		}
+		reencoded, err := goptuna.DistributionToJSON(decoded)
+		if err != nil {
+			t.Errorf("DistributionToJSON(%#v) should not return err, but got %s", decoded, err)
+			return
+		}
+		if !bytes.Equal(reencoded, encoded) {
+			t.Errorf("encoding is not stable: %s != %s", reencoded, encoded)
+			return
+		}
+	}
+}
+
+func TestDistributionToJSON_IntDistributionWithZeroStep(t *testing.T) {
+	encoded, err := goptuna.DistributionToJSON(goptuna.IntDistribution{Low: 1, High: 10})
+	if err != nil {
+		t.Errorf("should not return err, but got %s", err)
+		return
+	}
+	expected := `{"name":"IntDistribution","attributes":{"high":10,"low":1,"step":1,"log":false}}`
+	if string(encoded) != expected {
+		t.Errorf("DistributionToJSON() = %s, want %s", encoded, expected)
+	}
+}
+
+func TestJSONToDistribution_UnknownDistribution(t *testing.T) {
+	_, err := goptuna.JSONToDistribution([]byte(`{"name": "UnknownDistribution", "attributes": {}}`))
+	if err != goptuna.ErrUnknownDistribution {
+		t.Errorf("should return ErrUnknownDistribution, but got %v", err)
+	}
+
+	type unknownDistribution struct{ goptuna.UniformDistribution }
+	_, err = goptuna.DistributionToJSON(unknownDistribution{})
+	if err != goptuna.ErrUnknownDistribution {
+		t.Errorf("should return ErrUnknownDistribution, but got %v", err)
+	}
+}
+
+func TestCheckDistributionCompatibility(t *testing.T) {
+	tests := []struct {
+		name    string
+		old     interface{}
+		new     interface{}
+		wantErr bool
+	}{
+		{
+			name:    "same distribution",
+			old:     goptuna.UniformDistribution{Low: 0, High: 1},
+			new:     goptuna.UniformDistribution{Low: 0, High: 1},
+			wantErr: false,
+		},
+		{
+			name:    "different range",
+			old:     goptuna.UniformDistribution{Low: 0, High: 1},
+			new:     goptuna.UniformDistribution{Low: 0, High: 10},
+			wantErr: false,
+		},
+		{
+			name:    "uniform and float distribution",
+			old:     goptuna.UniformDistribution{Low: 0, High: 1},
+			new:     goptuna.FloatDistribution{Low: 0, High: 1},
+			wantErr: false,
+		},
+		{
+			name:    "log uniform and log float distribution",
+			old:     goptuna.LogUniformDistribution{Low: 1e-5, High: 1},
+			new:     goptuna.FloatDistribution{Low: 1e-5, High: 1, Log: true},
+			wantErr: false,
+		},
+		{
+			name:    "uniform and log uniform",
+			old:     goptuna.UniformDistribution{Low: 0, High: 1},
+			new:     goptuna.LogUniformDistribution{Low: 1e-5, High: 1},
+			wantErr: true,
+		},
+		{
+			name:    "step int uniform and int distribution",
+			old:     goptuna.StepIntUniformDistribution{Low: 0, High: 10, Step: 2},
+			new:     goptuna.IntDistribution{Low: 0, High: 10, Step: 2},
+			wantErr: false,
+		},
+		{
+			name:    "int and float",
+			old:     goptuna.IntDistribution{Low: 0, High: 10, Step: 1},
+			new:     goptuna.FloatDistribution{Low: 0, High: 10},
+			wantErr: true,
+		},
+		{
+			name:    "same choices",
+			old:     goptuna.CategoricalDistribution{Choices: []interface{}{"a", 1}},
+			new:     goptuna.CategoricalDistribution{Choices: []interface{}{"a", 1}},
+			wantErr: false,
+		},
+		{
+			name:    "different choices",
+			old:     goptuna.CategoricalDistribution{Choices: []interface{}{"a", 1}},
+			new:     goptuna.CategoricalDistribution{Choices: []interface{}{"a", 2}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := goptuna.CheckDistributionCompatibility(tt.old, tt.new)
+			if errors.Is(err, goptuna.ErrIncompatibleDistribution) != tt.wantErr {
+				t.Errorf("err: %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJSONToDistribution_Optuna(t *testing.T) {
+	tests := []struct {
+		name     string
+		json     string
+		expected interface{}
+	}{
+		{
+			name:     "float distribution",
+			json:     `{"name": "FloatDistribution", "attributes": {"step": null, "low": 1e-05, "high": 1.0, "log": true}}`,
+			expected: goptuna.FloatDistribution{Low: 1e-5, High: 1.0, Log: true},
+		},
+		{
+			name:     "float distribution with step",
+			json:     `{"name": "FloatDistribution", "attributes": {"step": 0.1, "low": 0.0, "high": 1.0, "log": false}}`,
+			expected: goptuna.FloatDistribution{Low: 0, High: 1.0, Step: 0.1},
+		},
+		{
+			name:     "int distribution",
+			json:     `{"name": "IntDistribution", "attributes": {"log": false, "step": 2, "low": 0, "high": 10}}`,
+			expected: goptuna.IntDistribution{Low: 0, High: 10, Step: 2},
+		},
+		{
+			name:     "categorical distribution",
+			json:     `{"name": "CategoricalDistribution", "attributes": {"choices": [null, true, 1, 1.0, 1e-05, "foo"]}}`,
+			expected: goptuna.CategoricalDistribution{Choices: []interface{}{nil, true, 1, 1.0, 1e-05, "foo"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := goptuna.JSONToDistribution([]byte(tt.json))
+			if err != nil {
+				t.Errorf("JSONToDistribution should not be err, but got %s", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Must be the same, but %#v != %#v", got, tt.expected)
+			}
+		})
+	}
+
+	jsonBytes, err := goptuna.DistributionToJSON(goptuna.FloatDistribution{Low: 0, High: 1})
+	if err != nil {
+		t.Errorf("DistributionToJSON should not be err, but got %s", err)
+		return
+	}
+	expected := `{"name":"FloatDistribution","attributes":{"high":1,"low":0,"step":null,"log":false}}`
+	if string(jsonBytes) != expected {
+		t.Errorf("Must be the same, but %s != %s", jsonBytes, expected)
+	}
+}
+
 func TestDistributionToExternalRepresentation(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -142,10 +393,40 @@ func TestDistributionToExternalRepresentation(t *testing.T) {
 		},
 		{
 			name:         "categorical distribution",
-			distribution: &goptuna.CategoricalDistribution{Choices: []string{"a", "b", "c"}},
+			distribution: &goptuna.CategoricalDistribution{Choices: []interface{}{"a", "b", "c"}},
 			args:         2.0,
 			want:         "c",
 		},
+		{
+			name:         "float distribution",
+			distribution: &goptuna.FloatDistribution{Low: 1e-2, High: 1e3, Log: true},
+			args:         1e2,
+			want:         1e2,
+		},
+		{
+			name:         "float distribution with step",
+			distribution: &goptuna.FloatDistribution{Low: 0.5, High: 5.5, Step: 0.5},
+			args:         3.3,
+			want:         3.5,
+		},
+		{
+			name:         "float distribution with step is clipped",
+			distribution: &goptuna.FloatDistribution{Low: 0, High: 1, Step: 0.3},
+			args:         1.0,
+			want:         0.8999999999999999,
+		},
+		{
+			name:         "int distribution",
+			distribution: &goptuna.IntDistribution{Low: 1, High: 100, Step: 1, Log: true},
+			args:         31.6,
+			want:         32,
+		},
+		{
+			name:         "int distribution with step",
+			distribution: &goptuna.IntDistribution{Low: -1, High: 10, Step: 3},
+			args:         10.0,
+			want:         8,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -219,12 +500,12 @@ func TestDistributionSingle(t *testing.T) {
 		},
 		{
 			name:         "categorical distribution true",
-			distribution: &goptuna.CategoricalDistribution{Choices: []string{"a"}},
+			distribution: &goptuna.CategoricalDistribution{Choices: []interface{}{"a"}},
 			want:         true,
 		},
 		{
 			name:         "categorical distribution false",
-			distribution: &goptuna.CategoricalDistribution{Choices: []string{"a", "b", "c"}},
+			distribution: &goptuna.CategoricalDistribution{Choices: []interface{}{"a", "b", "c"}},
 			want:         false,
 		},
 	}
@@ -376,21 +657,51 @@ func TestDistributionContains(t *testing.T) {
 			args:         15,
 			want:         false,
 		},
+		{
+			name:         "float distribution on the step",
+			distribution: &goptuna.FloatDistribution{Low: 0.0, High: 1.0, Step: 0.1},
+			args:         0.1 * 7,
+			want:         true,
+		},
+		{
+			name:         "float distribution off the step",
+			distribution: &goptuna.FloatDistribution{Low: 0.0, High: 1.0, Step: 0.1},
+			args:         0.75,
+			want:         false,
+		},
+		{
+			name:         "int distribution on the step",
+			distribution: &goptuna.IntDistribution{Low: 1, High: 9, Step: 2},
+			args:         7,
+			want:         true,
+		},
+		{
+			name:         "int distribution off the step",
+			distribution: &goptuna.IntDistribution{Low: 1, High: 9, Step: 2},
+			args:         4,
+			want:         false,
+		},
+		{
+			name:         "int distribution with zero step",
+			distribution: &goptuna.IntDistribution{Low: 1, High: 9},
+			args:         4,
+			want:         true,
+		},
 		{
 			name:         "categorical distribution true",
-			distribution: &goptuna.CategoricalDistribution{Choices: []string{"a", "b", "c"}},
+			distribution: &goptuna.CategoricalDistribution{Choices: []interface{}{"a", "b", "c"}},
 			args:         1,
 			want:         true,
 		},
 		{
 			name:         "categorical distribution lower",
-			distribution: &goptuna.CategoricalDistribution{Choices: []string{"a", "b", "c"}},
+			distribution: &goptuna.CategoricalDistribution{Choices: []interface{}{"a", "b", "c"}},
 			args:         -1,
 			want:         false,
 		},
 		{
 			name:         "categorical distribution higher",
-			distribution: &goptuna.CategoricalDistribution{Choices: []string{"a", "b", "c"}},
+			distribution: &goptuna.CategoricalDistribution{Choices: []interface{}{"a", "b", "c"}},
 			args:         3,
 			want:         false,
 		},
@@ -0,0 +1,76 @@
+package goptuna_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/c-bata/goptuna"
+)
+
+// PowerOfTwoDistribution is a distribution on 2^LowExp, 2^(LowExp+1), ..., 2^HighExp.
+// The internal representation of a parameter value is the exponent.
+type PowerOfTwoDistribution struct {
+	LowExp  int `json:"low_exp"`
+	HighExp int `json:"high_exp"`
+}
+
+func (d PowerOfTwoDistribution) ToExternalRepr(ir float64) interface{} {
+	return 1 << int(math.Round(ir))
+}
+
+func (d PowerOfTwoDistribution) Single() bool {
+	return d.LowExp == d.HighExp
+}
+
+func (d PowerOfTwoDistribution) Contains(ir float64) bool {
+	exp := int(math.Round(ir))
+	return d.LowExp <= exp && exp <= d.HighExp
+}
+
+func (d PowerOfTwoDistribution) Sample(rng *rand.Rand) float64 {
+	return float64(rng.Intn(d.HighExp-d.LowExp+1) + d.LowExp)
+}
+
+func init() {
+	// User-defined distributions should be registered to encode them in JSON.
+	goptuna.RegisterDistribution("PowerOfTwoDistribution", PowerOfTwoDistribution{})
+}
+
+func Example_userDefinedDistribution() {
+	distribution := PowerOfTwoDistribution{LowExp: 4, HighExp: 10}
+	jsonBytes, _ := goptuna.DistributionToJSON(distribution)
+	fmt.Println(string(jsonBytes))
+	decoded, _ := goptuna.JSONToDistribution(jsonBytes)
+	fmt.Println(decoded == distribution)
+
+	study, _ := goptuna.CreateStudy(
+		"goptuna-example",
+		goptuna.StudyOptionSampler(goptuna.NewRandomSampler(goptuna.RandomSamplerOptionSeed(0))),
+		goptuna.StudyOptionLogger(nil),
+	)
+	_ = study.Optimize(func(trial goptuna.Trial) (float64, error) {
+		batchSize, err := trial.Suggest("batch_size", distribution)
+		if err != nil {
+			return 0, err
+		}
+		return math.Abs(float64(batchSize.(int)) - 100), nil
+	}, 10)
+	params, _ := study.GetBestParams()
+	fmt.Printf("Best batch size: %d\n", params["batch_size"])
+	// Output:
+	// {"name":"PowerOfTwoDistribution","attributes":{"low_exp":4,"high_exp":10}}
+	// true
+	// Best batch size: 128
+}
+
+func ExampleDistributionToJSON() {
+	encoded, err := goptuna.DistributionToJSON(goptuna.IntDistribution{Low: 1, High: 10, Step: 1})
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(string(encoded))
+
+	// Output:
+	// {"name":"IntDistribution","attributes":{"high":10,"low":1,"step":1,"log":false}}
+}
@@ -0,0 +1,108 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/c-bata/goptuna"
+)
+
+// CSVEncoder writes the trials as CSV rows one by one.
+// The columns must be decided before writing the first row, so please
+// use Columns to collect them from the trials.
+type CSVEncoder struct {
+	writer  *csv.Writer
+	columns []string
+	options options
+}
+
+// NewCSVEncoder returns a new CSVEncoder and writes the header line.
+func NewCSVEncoder(w io.Writer, columns []string, opts ...Option) (*CSVEncoder, error) {
+	if len(columns) == 0 {
+		return nil, errors.New("columns must not be empty")
+	}
+	writer := csv.NewWriter(w)
+	if err := writer.Write(columns); err != nil {
+		return nil, err
+	}
+	return &CSVEncoder{
+		writer:  writer,
+		columns: columns,
+		options: newOptions(opts),
+	}, nil
+}
+
+// Encode writes the trial as a CSV row. The values of the columns which are not
+// in the columns of the encoder are ignored. The trial is skipped if its state is filtered.
+func (e *CSVEncoder) Encode(trial goptuna.FrozenTrial) error {
+	if !e.options.filter(trial) {
+		return nil
+	}
+	row := flatten(trial, e.options)
+	record := make([]string, len(e.columns))
+	for i, column := range e.columns {
+		record[i] = formatCSVValue(row[column])
+	}
+	return e.writer.Write(record)
+}
+
+// Flush writes any buffered data to the underlying io.Writer.
+func (e *CSVEncoder) Flush() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+// JSONLEncoder writes the trials as JSON Lines one by one.
+// Unlike CSVEncoder, each line only contains the columns of the trial.
+// The keys are written in the same order as the columns of CSVEncoder.
+type JSONLEncoder struct {
+	writer  io.Writer
+	options options
+}
+
+// NewJSONLEncoder returns a new JSONLEncoder.
+func NewJSONLEncoder(w io.Writer, opts ...Option) *JSONLEncoder {
+	return &JSONLEncoder{
+		writer:  w,
+		options: newOptions(opts),
+	}
+}
+
+// Encode writes the trial as a JSON line.
+// The trial is skipped if its state is filtered.
+func (e *JSONLEncoder) Encode(trial goptuna.FrozenTrial) error {
+	if !e.options.filter(trial) {
+		return nil
+	}
+	row := flatten(trial, e.options)
+
+	// encoding/json sorts the keys of maps, so the object is written by hand.
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for _, column := range columns([]goptuna.FrozenTrial{trial}, e.options) {
+		v, ok := row[column]
+		if !ok {
+			continue
+		}
+		key, err := json.Marshal(column)
+		if err != nil {
+			return err
+		}
+		value, err := json.Marshal(jsonValue(v))
+		if err != nil {
+			return err
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteString("}\n")
+	_, err := e.writer.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,262 @@
+// Package export provides encoders to export the trials of the study
+// as CSV or JSON Lines. FrozenTrial is flattened into the following columns:
+//
+//	number, state, value, (values_0, values_1, ... for multi-objective studies),
+//	datetime_start, datetime_complete, duration, params_<name>,
+//	user_attrs_<key>, system_attrs_<key> and intermediate_values_<step>.
+//
+// The system attributes and the intermediate values are exported only if
+// the corresponding options are given. The duration is in seconds.
+package export
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/c-bata/goptuna"
+)
+
+// Format is a format of the exported trials.
+type Format string
+
+const (
+	// FormatCSV exports the trials as CSV with a header line.
+	FormatCSV Format = "csv"
+	// FormatJSONL exports each trial as a JSON object per line.
+	FormatJSONL Format = "jsonl"
+)
+
+const (
+	paramsPrefix             = "params_"
+	userAttrsPrefix          = "user_attrs_"
+	systemAttrsPrefix        = "system_attrs_"
+	intermediateValuesPrefix = "intermediate_values_"
+	valuesPrefix             = "values_"
+)
+
+// Option is a type of function to customize the exported columns and trials.
+type Option func(options *options)
+
+type options struct {
+	states             map[goptuna.TrialState]bool
+	systemAttrs        bool
+	intermediateValues bool
+}
+
+// OptionStates exports only the trials in the given states.
+func OptionStates(states ...goptuna.TrialState) Option {
+	return func(options *options) {
+		options.states = make(map[goptuna.TrialState]bool, len(states))
+		for _, state := range states {
+			options.states[state] = true
+		}
+	}
+}
+
+// OptionSystemAttrs exports the system attributes of the trials.
+func OptionSystemAttrs(enabled bool) Option {
+	return func(options *options) {
+		options.systemAttrs = enabled
+	}
+}
+
+// OptionIntermediateValues exports the intermediate values of the trials.
+func OptionIntermediateValues(enabled bool) Option {
+	return func(options *options) {
+		options.intermediateValues = enabled
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+func (o options) filter(trial goptuna.FrozenTrial) bool {
+	return o.states == nil || o.states[trial.State]
+}
+
+// Write exports the trials in the given format.
+func Write(w io.Writer, trials []goptuna.FrozenTrial, format Format, opts ...Option) error {
+	switch format {
+	case FormatCSV:
+		return WriteCSV(w, trials, opts...)
+	case FormatJSONL:
+		return WriteJSONL(w, trials, opts...)
+	}
+	return fmt.Errorf("unsupported format: %s", format)
+}
+
+// WriteCSV exports the trials as CSV.
+func WriteCSV(w io.Writer, trials []goptuna.FrozenTrial, opts ...Option) error {
+	encoder, err := NewCSVEncoder(w, Columns(trials, opts...), opts...)
+	if err != nil {
+		return err
+	}
+	for i := range trials {
+		if err = encoder.Encode(trials[i]); err != nil {
+			return err
+		}
+	}
+	return encoder.Flush()
+}
+
+// WriteJSONL exports the trials as JSON Lines.
+func WriteJSONL(w io.Writer, trials []goptuna.FrozenTrial, opts ...Option) error {
+	encoder := NewJSONLEncoder(w, opts...)
+	for i := range trials {
+		if err := encoder.Encode(trials[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Columns returns the column names of the given trials.
+func Columns(trials []goptuna.FrozenTrial, opts ...Option) []string {
+	return columns(trials, newOptions(opts))
+}
+
+func columns(trials []goptuna.FrozenTrial, o options) []string {
+	nValues := 0
+	params := make(map[string]bool)
+	userAttrs := make(map[string]bool)
+	systemAttrs := make(map[string]bool)
+	steps := make(map[int]bool)
+	for i := range trials {
+		if !o.filter(trials[i]) {
+			continue
+		}
+		if len(trials[i].Values) > nValues {
+			nValues = len(trials[i].Values)
+		}
+		for name := range trials[i].Params {
+			params[name] = true
+		}
+		for key := range trials[i].UserAttrs {
+			userAttrs[key] = true
+		}
+		if o.systemAttrs {
+			for key := range trials[i].SystemAttrs {
+				systemAttrs[key] = true
+			}
+		}
+		if o.intermediateValues {
+			for step := range trials[i].IntermediateValues {
+				steps[step] = true
+			}
+		}
+	}
+
+	columns := []string{"number", "state", "value"}
+	if nValues > 1 {
+		for i := 0; i < nValues; i++ {
+			columns = append(columns, valuesPrefix+strconv.Itoa(i))
+		}
+	}
+	columns = append(columns, "datetime_start", "datetime_complete", "duration")
+	columns = append(columns, sortedKeys(params, paramsPrefix)...)
+	columns = append(columns, sortedKeys(userAttrs, userAttrsPrefix)...)
+	columns = append(columns, sortedKeys(systemAttrs, systemAttrsPrefix)...)
+
+	sortedSteps := make([]int, 0, len(steps))
+	for step := range steps {
+		sortedSteps = append(sortedSteps, step)
+	}
+	sort.Ints(sortedSteps)
+	for _, step := range sortedSteps {
+		columns = append(columns, intermediateValuesPrefix+strconv.Itoa(step))
+	}
+	return columns
+}
+
+func sortedKeys(m map[string]bool, prefix string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, prefix+key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// hasValue returns true if the value of the trial is registered. The last
+// intermediate value of the pruned trial is registered as its value if present.
+func hasValue(trial goptuna.FrozenTrial) bool {
+	switch trial.State {
+	case goptuna.TrialStateComplete:
+		return true
+	case goptuna.TrialStatePruned:
+		return len(trial.IntermediateValues) > 0
+	}
+	return false
+}
+
+// flatten converts the trial into the column values.
+// The column is not contained in the returned map if the trial doesn't have it.
+func flatten(trial goptuna.FrozenTrial, o options) map[string]interface{} {
+	row := make(map[string]interface{}, 8+len(trial.Params)+len(trial.UserAttrs))
+	row["number"] = trial.Number
+	row["state"] = strings.ToUpper(trial.State.String())
+	if hasValue(trial) {
+		row["value"] = trial.Value
+		if len(trial.Values) > 1 {
+			for i := range trial.Values {
+				row[valuesPrefix+strconv.Itoa(i)] = trial.Values[i]
+			}
+		}
+	}
+	if !trial.DatetimeStart.IsZero() {
+		row["datetime_start"] = trial.DatetimeStart.Format(time.RFC3339Nano)
+	}
+	if !trial.DatetimeComplete.IsZero() {
+		row["datetime_complete"] = trial.DatetimeComplete.Format(time.RFC3339Nano)
+		if !trial.DatetimeStart.IsZero() {
+			row["duration"] = trial.DatetimeComplete.Sub(trial.DatetimeStart).Seconds()
+		}
+	}
+	for name := range trial.Params {
+		row[paramsPrefix+name] = trial.Params[name]
+	}
+	for key := range trial.UserAttrs {
+		row[userAttrsPrefix+key] = trial.UserAttrs[key]
+	}
+	if o.systemAttrs {
+		for key := range trial.SystemAttrs {
+			row[systemAttrsPrefix+key] = trial.SystemAttrs[key]
+		}
+	}
+	if o.intermediateValues {
+		for step := range trial.IntermediateValues {
+			row[intermediateValuesPrefix+strconv.Itoa(step)] = trial.IntermediateValues[step]
+		}
+	}
+	return row
+}
+
+func formatCSVValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// jsonValue replaces NaN and Inf with nil because they are not supported by JSON.
+func jsonValue(v interface{}) interface{} {
+	if f, ok := v.(float64); ok && (math.IsNaN(f) || math.IsInf(f, 0)) {
+		return nil
+	}
+	return v
+}
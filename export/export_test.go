@@ -0,0 +1,149 @@
+package export_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/c-bata/goptuna"
+	"github.com/c-bata/goptuna/export"
+)
+
+func testTrials() []goptuna.FrozenTrial {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []goptuna.FrozenTrial{
+		{
+			Number:             0,
+			State:              goptuna.TrialStateComplete,
+			Value:              0.5,
+			Values:             []float64{0.5},
+			IntermediateValues: map[int]float64{0: 1, 1: 0.5},
+			DatetimeStart:      start,
+			DatetimeComplete:   start.Add(1500 * time.Millisecond),
+			Params:             map[string]interface{}{"x": 0.25, "optimizer": "adam"},
+			UserAttrs:          map[string]string{"note": "a,b"},
+			SystemAttrs:        map[string]string{"fixed_params": "{}"},
+		},
+		{
+			Number:             1,
+			State:              goptuna.TrialStatePruned,
+			Value:              2,
+			Values:             []float64{2},
+			IntermediateValues: map[int]float64{0: 2},
+			DatetimeStart:      start,
+			DatetimeComplete:   start.Add(time.Second),
+			Params:             map[string]interface{}{"x": 1.0, "n": 3},
+			UserAttrs:          map[string]string{},
+			SystemAttrs:        map[string]string{},
+		},
+		{
+			Number:        2,
+			State:         goptuna.TrialStateRunning,
+			DatetimeStart: start,
+			Params:        map[string]interface{}{},
+		},
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []export.Option
+		expected string
+	}{
+		{
+			name: "default",
+			opts: nil,
+			expected: `number,state,value,datetime_start,datetime_complete,duration,params_n,params_optimizer,params_x,user_attrs_note
+0,COMPLETE,0.5,2021-01-01T00:00:00Z,2021-01-01T00:00:01.5Z,1.5,,adam,0.25,"a,b"
+1,PRUNED,2,2021-01-01T00:00:00Z,2021-01-01T00:00:01Z,1,3,,1,
+2,RUNNING,,2021-01-01T00:00:00Z,,,,,,
+`,
+		},
+		{
+			name: "filter states",
+			opts: []export.Option{
+				export.OptionStates(goptuna.TrialStatePruned, goptuna.TrialStateRunning),
+			},
+			expected: `number,state,value,datetime_start,datetime_complete,duration,params_n,params_x
+1,PRUNED,2,2021-01-01T00:00:00Z,2021-01-01T00:00:01Z,1,3,1
+2,RUNNING,,2021-01-01T00:00:00Z,,,,
+`,
+		},
+		{
+			name: "system attrs and intermediate values",
+			opts: []export.Option{
+				export.OptionStates(goptuna.TrialStateComplete),
+				export.OptionSystemAttrs(true),
+				export.OptionIntermediateValues(true),
+			},
+			expected: `number,state,value,datetime_start,datetime_complete,duration,params_optimizer,params_x,user_attrs_note,system_attrs_fixed_params,intermediate_values_0,intermediate_values_1
+0,COMPLETE,0.5,2021-01-01T00:00:00Z,2021-01-01T00:00:01.5Z,1.5,adam,0.25,"a,b",{},1,0.5
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := export.Write(&buf, testTrials(), export.FormatCSV, tt.opts...)
+			if err != nil {
+				t.Errorf("err: %v != nil", err)
+				return
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("should be\n%s\nbut got\n%s", tt.expected, buf.String())
+			}
+		})
+	}
+}
+
+func TestWriteJSONL(t *testing.T) {
+	var buf bytes.Buffer
+	err := export.Write(&buf, testTrials(), export.FormatJSONL,
+		export.OptionStates(goptuna.TrialStateComplete, goptuna.TrialStatePruned),
+		export.OptionIntermediateValues(true))
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	expected := []string{
+		`{"number":0,"state":"COMPLETE","value":0.5,"datetime_start":"2021-01-01T00:00:00Z","datetime_complete":"2021-01-01T00:00:01.5Z","duration":1.5,"params_optimizer":"adam","params_x":0.25,"user_attrs_note":"a,b","intermediate_values_0":1,"intermediate_values_1":0.5}`,
+		`{"number":1,"state":"PRUNED","value":2,"datetime_start":"2021-01-01T00:00:00Z","datetime_complete":"2021-01-01T00:00:01Z","duration":1,"params_n":3,"params_x":1,"intermediate_values_0":2}`,
+	}
+	actual := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(actual) != len(expected) {
+		t.Errorf("should be %d lines, but got %d", len(expected), len(actual))
+		return
+	}
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("should be %s, but got %s", expected[i], actual[i])
+		}
+	}
+}
+
+func TestWrite_UnsupportedFormat(t *testing.T) {
+	var buf bytes.Buffer
+	err := export.Write(&buf, testTrials(), export.Format("xml"))
+	if err == nil {
+		t.Errorf("should be error")
+	}
+}
+
+func TestWriteJSONL_PrunedWithoutIntermediateValues(t *testing.T) {
+	var buf bytes.Buffer
+	err := export.Write(&buf, []goptuna.FrozenTrial{{
+		Number: 0,
+		State:  goptuna.TrialStatePruned,
+	}}, export.FormatJSONL)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	expected := `{"number":0,"state":"PRUNED"}` + "\n"
+	if buf.String() != expected {
+		t.Errorf("should be %s, but got %s", expected, buf.String())
+	}
+}
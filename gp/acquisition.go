@@ -0,0 +1,34 @@
+package gp
+
+import "math"
+
+// logEIThreshold is the threshold of z below which the asymptotic expansion is
+// used to compute log(z * Phi(z) + phi(z)) without the catastrophic cancellation.
+const logEIThreshold = -10.0
+
+func normPdf(x float64) float64 {
+	return math.Exp(-0.5*x*x) / math.Sqrt(2*math.Pi)
+}
+
+func normCdf(x float64) float64 {
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+// logH returns log(z * Phi(z) + phi(z)) in a numerically stable way.
+func logH(z float64) float64 {
+	if z >= logEIThreshold {
+		return math.Log(z*normCdf(z) + normPdf(z))
+	}
+	// z * Phi(z) + phi(z) = phi(z) * (1/t^2 - 3/t^4 + 15/t^6 - 105/t^8 + ...) where t = -z.
+	t2 := z * z
+	series := 1/t2 - 3/(t2*t2) + 15/(t2*t2*t2) - 105/(t2*t2*t2*t2)
+	return -0.5*z*z - 0.5*math.Log(2*math.Pi) + math.Log(series)
+}
+
+// logExpectedImprovement returns the logarithm of the expected improvement over
+// the best value for minimization.
+func logExpectedImprovement(mean, variance, best float64) float64 {
+	sigma := math.Sqrt(variance)
+	z := (best - mean) / sigma
+	return math.Log(sigma) + logH(z)
+}
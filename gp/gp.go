@@ -0,0 +1,266 @@
+package gp
+
+import (
+	"errors"
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/optimize"
+)
+
+const (
+	// minNoiseVariance is added to the diagonal of the kernel matrix for numerical stability.
+	minNoiseVariance = 1e-6
+	// The log-scaled hyperparameters are regularized by Gaussian priors.
+	priorLogLengthScaleMean = 0.0
+	priorLogLengthScaleStd  = 2.0
+	priorLogVarianceMean    = 0.0
+	priorLogVarianceStd     = 2.0
+	priorLogNoiseMean       = -4.0
+	priorLogNoiseStd        = 2.0
+)
+
+var errNotPositiveDefinite = errors.New("kernel matrix is not positive definite")
+
+// kernelParams holds the hyperparameters of Matérn 5/2 kernel with ARD.
+type kernelParams struct {
+	lengthScales  []float64
+	variance      float64
+	noiseVariance float64
+}
+
+func (p kernelParams) toLog() []float64 {
+	x := make([]float64, 0, len(p.lengthScales)+2)
+	for _, l := range p.lengthScales {
+		x = append(x, math.Log(l))
+	}
+	return append(x, math.Log(p.variance), math.Log(p.noiseVariance))
+}
+
+func kernelParamsFromLog(x []float64) kernelParams {
+	d := len(x) - 2
+	lengthScales := make([]float64, d)
+	for i := 0; i < d; i++ {
+		lengthScales[i] = math.Exp(x[i])
+	}
+	return kernelParams{
+		lengthScales:  lengthScales,
+		variance:      math.Exp(x[d]),
+		noiseVariance: math.Exp(x[d+1]),
+	}
+}
+
+// matern52 returns the Matérn 5/2 kernel value and the scaled squared distances
+// of each dimension, which are used to compute the gradients.
+func matern52(x1, x2 []float64, params kernelParams, scaled []float64) float64 {
+	var r2 float64
+	for i := range x1 {
+		d := (x1[i] - x2[i]) / params.lengthScales[i]
+		if scaled != nil {
+			scaled[i] = d * d
+		}
+		r2 += d * d
+	}
+	r := math.Sqrt(r2)
+	sqrt5r := math.Sqrt(5) * r
+	return params.variance * (1 + sqrt5r + 5.0/3.0*r2) * math.Exp(-sqrt5r)
+}
+
+// gaussianProcess is a Gaussian process regression model with Matérn 5/2 kernel.
+type gaussianProcess struct {
+	x      [][]float64
+	params kernelParams
+	alpha  *mat.VecDense // K^-1 y
+	kInv   *mat.SymDense
+}
+
+// negLogMarginalLikelihood returns the negative log marginal likelihood plus
+// the negative log prior and its gradient with respect to the log-scaled hyperparameters.
+func negLogMarginalLikelihood(x [][]float64, y []float64, logParams []float64, grad []float64) (float64, error) {
+	n := len(x)
+	d := len(logParams) - 2
+	params := kernelParamsFromLog(logParams)
+
+	k := mat.NewSymDense(n, nil)
+	// dK/dlog(l_i) for each dimension
+	var dK []*mat.SymDense
+	if grad != nil {
+		dK = make([]*mat.SymDense, d)
+		for i := range dK {
+			dK[i] = mat.NewSymDense(n, nil)
+		}
+	}
+	scaled := make([]float64, d)
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			v := matern52(x[i], x[j], params, scaled)
+			if i == j {
+				k.SetSym(i, j, v+params.noiseVariance+minNoiseVariance)
+				continue
+			}
+			k.SetSym(i, j, v)
+			if grad != nil {
+				r := 0.0
+				for _, s := range scaled {
+					r += s
+				}
+				r = math.Sqrt(r)
+				common := params.variance * 5.0 / 3.0 * (1 + math.Sqrt(5)*r) * math.Exp(-math.Sqrt(5)*r)
+				for l := 0; l < d; l++ {
+					dK[l].SetSym(i, j, common*scaled[l])
+				}
+			}
+		}
+	}
+
+	var chol mat.Cholesky
+	if ok := chol.Factorize(k); !ok {
+		return 0, errNotPositiveDefinite
+	}
+	alpha := mat.NewVecDense(n, nil)
+	if err := chol.SolveVecTo(alpha, mat.NewVecDense(n, y)); err != nil {
+		return 0, err
+	}
+	nll := 0.5*mat.Dot(mat.NewVecDense(n, y), alpha) + 0.5*chol.LogDet() + 0.5*float64(n)*math.Log(2*math.Pi)
+	nll += negLogPrior(logParams, grad)
+	if grad == nil {
+		return nll, nil
+	}
+
+	var kInv mat.SymDense
+	if err := chol.InverseTo(&kInv); err != nil {
+		return 0, err
+	}
+	// d(nll)/dθ = -0.5 tr((αα^T - K^-1) dK/dθ)
+	w := mat.NewSymDense(n, nil)
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			w.SetSym(i, j, alpha.AtVec(i)*alpha.AtVec(j)-kInv.At(i, j))
+		}
+	}
+	trace := func(dk func(i, j int) float64) float64 {
+		var t float64
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				t += w.At(i, j) * dk(i, j)
+			}
+		}
+		return t
+	}
+	for l := 0; l < d; l++ {
+		grad[l] += -0.5 * trace(dK[l].At)
+	}
+	grad[d] += -0.5 * trace(func(i, j int) float64 {
+		if i == j {
+			return params.variance
+		}
+		return k.At(i, j)
+	})
+	grad[d+1] += -0.5 * trace(func(i, j int) float64 {
+		if i == j {
+			return params.noiseVariance
+		}
+		return 0
+	})
+	return nll, nil
+}
+
+// negLogPrior returns the negative log prior and sets its gradient to grad.
+func negLogPrior(logParams []float64, grad []float64) float64 {
+	d := len(logParams) - 2
+	var v float64
+	gaussian := func(i int, mean, std float64) {
+		z := (logParams[i] - mean) / std
+		v += 0.5 * z * z
+		if grad != nil {
+			grad[i] = z / std
+		}
+	}
+	for i := 0; i < d; i++ {
+		gaussian(i, priorLogLengthScaleMean, priorLogLengthScaleStd)
+	}
+	gaussian(d, priorLogVarianceMean, priorLogVarianceStd)
+	gaussian(d+1, priorLogNoiseMean, priorLogNoiseStd)
+	return v
+}
+
+// fitGaussianProcess fits the hyperparameters by maximizing the marginal likelihood
+// starting from the given initial parameters. The targets y should be standardized.
+func fitGaussianProcess(x [][]float64, y []float64, init kernelParams) (*gaussianProcess, error) {
+	problem := optimize.Problem{
+		Func: func(logParams []float64) float64 {
+			v, err := negLogMarginalLikelihood(x, y, logParams, nil)
+			if err != nil {
+				return math.Inf(1)
+			}
+			return v
+		},
+		Grad: func(grad, logParams []float64) {
+			for i := range grad {
+				grad[i] = 0
+			}
+			if _, err := negLogMarginalLikelihood(x, y, logParams, grad); err != nil {
+				for i := range grad {
+					grad[i] = 0
+				}
+			}
+		},
+	}
+
+	params := init
+	// The error is ignored because the best parameters found so far are
+	// available even if the optimization fails to converge.
+	result, _ := optimize.Minimize(problem, init.toLog(), &optimize.Settings{
+		MajorIterations: 200,
+	}, &optimize.LBFGS{})
+	if result != nil && !math.IsInf(result.F, 0) && !math.IsNaN(result.F) {
+		params = kernelParamsFromLog(result.X)
+	}
+	return newGaussianProcess(x, y, params)
+}
+
+func newGaussianProcess(x [][]float64, y []float64, params kernelParams) (*gaussianProcess, error) {
+	n := len(x)
+	k := mat.NewSymDense(n, nil)
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			v := matern52(x[i], x[j], params, nil)
+			if i == j {
+				v += params.noiseVariance + minNoiseVariance
+			}
+			k.SetSym(i, j, v)
+		}
+	}
+	var chol mat.Cholesky
+	if ok := chol.Factorize(k); !ok {
+		return nil, errNotPositiveDefinite
+	}
+	alpha := mat.NewVecDense(n, nil)
+	if err := chol.SolveVecTo(alpha, mat.NewVecDense(n, y)); err != nil {
+		return nil, err
+	}
+	kInv := mat.NewSymDense(n, nil)
+	if err := chol.InverseTo(kInv); err != nil {
+		return nil, err
+	}
+	return &gaussianProcess{
+		x:      x,
+		params: params,
+		alpha:  alpha,
+		kInv:   kInv,
+	}, nil
+}
+
+// predict returns the posterior mean and variance of the latent function at x.
+func (g *gaussianProcess) predict(x []float64) (float64, float64) {
+	n := len(g.x)
+	kStar := mat.NewVecDense(n, nil)
+	for i := 0; i < n; i++ {
+		kStar.SetVec(i, matern52(x, g.x[i], g.params, nil))
+	}
+	mean := mat.Dot(kStar, g.alpha)
+	var v mat.VecDense
+	v.MulVec(g.kInv, kStar)
+	variance := g.params.variance - mat.Dot(kStar, &v)
+	return mean, math.Max(variance, 1e-12)
+}
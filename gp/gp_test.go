@@ -0,0 +1,89 @@
+package gp
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestNegLogMarginalLikelihood_Gradient(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	x := make([][]float64, 8)
+	y := make([]float64, len(x))
+	for i := range x {
+		x[i] = []float64{rng.Float64(), rng.Float64()}
+		y[i] = math.Sin(3*x[i][0]) + x[i][1]
+	}
+	logParams := []float64{-0.5, 0.3, 0.2, -3}
+
+	grad := make([]float64, len(logParams))
+	if _, err := negLogMarginalLikelihood(x, y, logParams, grad); err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	const h = 1e-6
+	for i := range logParams {
+		plus := append([]float64{}, logParams...)
+		minus := append([]float64{}, logParams...)
+		plus[i] += h
+		minus[i] -= h
+		fPlus, _ := negLogMarginalLikelihood(x, y, plus, nil)
+		fMinus, _ := negLogMarginalLikelihood(x, y, minus, nil)
+		want := (fPlus - fMinus) / (2 * h)
+		if math.Abs(grad[i]-want) > 1e-4*math.Max(1, math.Abs(want)) {
+			t.Errorf("grad[%d] = %f, want %f", i, grad[i], want)
+		}
+	}
+}
+
+func TestGaussianProcess_Predict(t *testing.T) {
+	x := [][]float64{{0}, {0.25}, {0.5}, {0.75}, {1}}
+	y := []float64{1, -1, 0.5, 0, -0.5}
+	model, err := fitGaussianProcess(x, y, kernelParams{
+		lengthScales:  []float64{1},
+		variance:      1,
+		noiseVariance: math.Exp(priorLogNoiseMean),
+	})
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+
+	for i := range x {
+		mean, variance := model.predict(x[i])
+		if math.Abs(mean-y[i]) > 0.2 {
+			t.Errorf("mean at %v = %f, want %f", x[i], mean, y[i])
+		}
+		farMean, farVariance := model.predict([]float64{x[i][0] + 10})
+		if farVariance <= variance {
+			t.Errorf("variance far from the observations %f should be larger than %f", farVariance, variance)
+		}
+		if math.Abs(farMean) > 1e-3 {
+			t.Errorf("mean far from the observations = %f, want 0", farMean)
+		}
+	}
+}
+
+func TestLogH(t *testing.T) {
+	for _, z := range []float64{-30, -12, -10.5, -3, 0, 2, 10} {
+		got := logH(z)
+		if math.IsInf(got, 0) || math.IsNaN(got) {
+			t.Errorf("logH(%f) = %f, want finite value", z, got)
+		}
+	}
+	// The asymptotic expansion is continuous with the exact value at the threshold.
+	exact := math.Log(logEIThreshold*normCdf(logEIThreshold) + normPdf(logEIThreshold))
+	if got := logH(logEIThreshold - 1e-9); math.Abs(got-exact) > 1e-3 {
+		t.Errorf("logH() = %f, want %f", got, exact)
+	}
+	// log EI is monotonically increasing in z.
+	prev := math.Inf(-1)
+	for z := -40.0; z < 5; z += 0.1 {
+		got := logH(z)
+		if got <= prev {
+			t.Errorf("logH(%f) = %f should be larger than %f", z, got, prev)
+			return
+		}
+		prev = got
+	}
+}
@@ -0,0 +1,261 @@
+// Package gp provides a sampler based on Gaussian process-based Bayesian optimization.
+//
+// The sampler fits a Gaussian process with Matérn 5/2 kernel and automatic relevance
+// determination (ARD) to the completed trials, then proposes the point which maximizes
+// the logarithm of the expected improvement (log EI). The kernel hyperparameters are
+// fitted by maximizing the marginal likelihood.
+// See "Unexpected Improvements to Expected Improvement for Bayesian Optimization"
+// by Ament et al. for log EI.
+package gp
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+
+	"github.com/c-bata/goptuna"
+	"gonum.org/v1/gonum/optimize"
+)
+
+var _ goptuna.RelativeSampler = &Sampler{}
+
+const (
+	// nLocalSearchStarts is the number of the best candidates to start the local search.
+	nLocalSearchStarts = 5
+	// perturbationStd is the standard deviation of the perturbations around the
+	// best observations in the encoded search space.
+	perturbationStd = 0.05
+)
+
+// Sampler returns the next search points by using Gaussian process-based Bayesian optimization.
+// The parameters are sampled by the independent sampler of the study until the number of
+// the completed trials reaches nStartupTrials.
+type Sampler struct {
+	mu             sync.Mutex
+	rng            *rand.Rand
+	nStartupTrials int
+	nCandidates    int
+}
+
+// NewSampler returns the GP sampler.
+func NewSampler(opts ...SamplerOption) *Sampler {
+	sampler := &Sampler{
+		rng:            rand.New(rand.NewSource(0)),
+		nStartupTrials: 10,
+		nCandidates:    1024,
+	}
+	for _, opt := range opts {
+		opt(sampler)
+	}
+	return sampler
+}
+
+// SampleRelative samples multiple dimensional parameters in a given search space.
+func (s *Sampler) SampleRelative(
+	study *goptuna.Study,
+	trial goptuna.FrozenTrial,
+	searchSpace map[string]interface{},
+) (map[string]float64, error) {
+	if study.IsMultiObjective() {
+		return nil, nil
+	}
+
+	names := make([]string, 0, len(searchSpace))
+	for name := range searchSpace {
+		single, err := goptuna.DistributionIsSingle(searchSpace[name])
+		if err != nil {
+			return nil, goptuna.ErrUnsupportedSearchSpace
+		}
+		if !single {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+	sort.Strings(names)
+	space, err := newSearchSpace(names, searchSpace)
+	if err != nil {
+		return nil, err
+	}
+
+	trials, err := study.GetTrials()
+	if err != nil && err != goptuna.ErrTrialsPartiallyDeleted {
+		return nil, err
+	}
+	var x [][]float64
+	var y []float64
+	for i := range trials {
+		if trials[i].State != goptuna.TrialStateComplete {
+			continue
+		}
+		if math.IsNaN(trials[i].Value) || math.IsInf(trials[i].Value, 0) {
+			continue
+		}
+		row, ok := space.encode(trials[i].InternalParams)
+		if !ok {
+			continue
+		}
+		value := trials[i].Value
+		if study.Direction() == goptuna.StudyDirectionMaximize {
+			value = -value
+		}
+		x = append(x, row)
+		y = append(y, value)
+	}
+	if len(y) == 0 || len(y) < s.nStartupTrials {
+		return nil, nil
+	}
+	standardize(y)
+
+	init := kernelParams{
+		lengthScales:  make([]float64, space.dim()),
+		variance:      1,
+		noiseVariance: math.Exp(priorLogNoiseMean),
+	}
+	for i := range init.lengthScales {
+		init.lengthScales[i] = 1
+	}
+	model, err := fitGaussianProcess(x, y, init)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	next := s.optimizeAcquisition(model, space, x, y)
+	return space.decode(next), nil
+}
+
+// optimizeAcquisition returns the encoded point which maximizes log EI. The random
+// candidates and the perturbations of the best observations are evaluated first,
+// then the best candidates are refined by the local search over the numerical columns.
+func (s *Sampler) optimizeAcquisition(model *gaussianProcess, space *searchSpace, x [][]float64, y []float64) []float64 {
+	best := math.Inf(1)
+	for _, v := range y {
+		best = math.Min(best, v)
+	}
+	acquisition := func(point []float64) float64 {
+		mean, variance := model.predict(point)
+		return logExpectedImprovement(mean, variance, best)
+	}
+
+	order := make([]int, len(y))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		return y[order[i]] < y[order[j]]
+	})
+
+	type candidate struct {
+		x     []float64
+		value float64
+	}
+	candidates := make([]candidate, 0, s.nCandidates)
+	for i := 0; i < s.nCandidates; i++ {
+		var point []float64
+		if i%2 == 0 {
+			point = space.sample(s.rng)
+		} else {
+			point = s.perturb(space, x[order[(i/2)%minInt(len(order), nLocalSearchStarts)]])
+		}
+		point = space.round(point)
+		candidates = append(candidates, candidate{x: point, value: acquisition(point)})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].value > candidates[j].value
+	})
+	if len(candidates) == 0 {
+		return space.sample(s.rng)
+	}
+
+	result := candidates[0]
+	for i := 0; i < minInt(len(candidates), nLocalSearchStarts); i++ {
+		point := space.round(localSearch(space, candidates[i].x, acquisition))
+		if value := acquisition(point); value > result.value {
+			result = candidate{x: point, value: value}
+		}
+	}
+	return result.x
+}
+
+func (s *Sampler) perturb(space *searchSpace, x []float64) []float64 {
+	point := make([]float64, len(x))
+	copy(point, x)
+	for j := range point {
+		if space.continuous[j] {
+			point[j] = clip(point[j] + s.rng.NormFloat64()*perturbationStd)
+		}
+	}
+	return point
+}
+
+// localSearch maximizes the acquisition function over the numerical columns
+// by Nelder-Mead method. The categorical columns are fixed.
+func localSearch(space *searchSpace, x0 []float64, acquisition func([]float64) float64) []float64 {
+	var indices []int
+	for j, c := range space.continuous {
+		if c {
+			indices = append(indices, j)
+		}
+	}
+	if len(indices) == 0 {
+		return x0
+	}
+	toPoint := func(z []float64) []float64 {
+		point := make([]float64, len(x0))
+		copy(point, x0)
+		for k, j := range indices {
+			point[j] = clip(z[k])
+		}
+		return point
+	}
+	z0 := make([]float64, len(indices))
+	for k, j := range indices {
+		z0[k] = x0[j]
+	}
+	problem := optimize.Problem{
+		Func: func(z []float64) float64 {
+			return -acquisition(toPoint(z))
+		},
+	}
+	result, err := optimize.Minimize(problem, z0, &optimize.Settings{
+		FuncEvaluations: 100 * len(indices),
+	}, &optimize.NelderMead{})
+	if result == nil || (err != nil && math.IsInf(result.F, 0)) {
+		return x0
+	}
+	return toPoint(result.X)
+}
+
+func standardize(y []float64) {
+	var mean float64
+	for _, v := range y {
+		mean += v
+	}
+	mean /= float64(len(y))
+	var variance float64
+	for _, v := range y {
+		variance += (v - mean) * (v - mean)
+	}
+	std := math.Sqrt(variance / float64(len(y)))
+	if std == 0 {
+		std = 1
+	}
+	for i := range y {
+		y[i] = (y[i] - mean) / std
+	}
+}
+
+func clip(x float64) float64 {
+	return math.Min(math.Max(x, 0), 1)
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,31 @@
+package gp
+
+import (
+	"math/rand"
+)
+
+// SamplerOption is a type of the function to customizing GP sampler.
+type SamplerOption func(sampler *Sampler)
+
+// SamplerOptionSeed sets seed number.
+func SamplerOptionSeed(seed int64) SamplerOption {
+	return func(sampler *Sampler) {
+		sampler.rng = rand.New(rand.NewSource(seed))
+	}
+}
+
+// SamplerOptionNStartupTrials sets the number of startup trials, which are
+// sampled by the independent sampler of the study.
+func SamplerOptionNStartupTrials(nStartupTrials int) SamplerOption {
+	return func(sampler *Sampler) {
+		sampler.nStartupTrials = nStartupTrials
+	}
+}
+
+// SamplerOptionNCandidates sets the number of random candidates to evaluate
+// the acquisition function before the local search.
+func SamplerOptionNCandidates(nCandidates int) SamplerOption {
+	return func(sampler *Sampler) {
+		sampler.nCandidates = nCandidates
+	}
+}
@@ -0,0 +1,93 @@
+package gp_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/c-bata/goptuna"
+	"github.com/c-bata/goptuna/gp"
+)
+
+func TestSampler_Optimize(t *testing.T) {
+	study, err := goptuna.CreateStudy(
+		"gp",
+		goptuna.StudyOptionRelativeSampler(gp.NewSampler(
+			gp.SamplerOptionSeed(0),
+			gp.SamplerOptionNStartupTrials(5))),
+		goptuna.StudyOptionSampler(goptuna.NewRandomSampler(goptuna.RandomSamplerOptionSeed(0))),
+		goptuna.StudyOptionLogger(nil),
+	)
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	objective := func(trial goptuna.Trial) (float64, error) {
+		x1, _ := trial.SuggestFloat("x1", -10, 10)
+		x2, _ := trial.SuggestFloat("x2", -10, 10)
+		return math.Pow(x1-2, 2) + math.Pow(x2+5, 2), nil
+	}
+	if err = study.Optimize(objective, 25); err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+
+	value, err := study.GetBestValue()
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	if value > 1 {
+		t.Errorf("best value %f should be smaller than 1", value)
+	}
+}
+
+func TestSampler_MixedSearchSpace(t *testing.T) {
+	study, err := goptuna.CreateStudy(
+		"gp",
+		goptuna.StudyOptionDirection(goptuna.StudyDirectionMaximize),
+		goptuna.StudyOptionRelativeSampler(gp.NewSampler(
+			gp.SamplerOptionSeed(0),
+			gp.SamplerOptionNStartupTrials(3),
+			gp.SamplerOptionNCandidates(64))),
+		goptuna.StudyOptionLogger(nil),
+	)
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	objective := func(trial goptuna.Trial) (float64, error) {
+		x, _ := trial.SuggestFloat("x", -1, 1)
+		lr, _ := trial.SuggestLogFloat("lr", 1e-5, 1e-1)
+		n, _ := trial.SuggestInt("n", 1, 9)
+		step, _ := trial.SuggestStepInt("step", 0, 10, 5)
+		q, _ := trial.SuggestDiscreteFloat("q", 0, 1, 0.25)
+		kind, _ := trial.SuggestCategorical("kind", []string{"a", "b", "c"})
+		value := -x*x - math.Log10(lr) - float64(n) + float64(step) + q
+		if kind == "b" {
+			value++
+		}
+		return value, nil
+	}
+	if err = study.Optimize(objective, 10); err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+
+	trials, err := study.GetTrials()
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	for _, trial := range trials {
+		for name, ir := range trial.InternalParams {
+			d, ok := trial.Distributions[name].(goptuna.Distribution)
+			if !ok || !d.Contains(ir) {
+				t.Errorf("trial %d: %s=%f is not contained in %#v",
+					trial.Number, name, ir, trial.Distributions[name])
+			}
+		}
+		if trial.State != goptuna.TrialStateComplete {
+			t.Errorf("trial %d should be completed, but got %s", trial.Number, trial.State)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package gp
+
+import (
+	"math/rand"
+
+	"github.com/c-bata/goptuna"
+	"github.com/c-bata/goptuna/internal/numerical"
+)
+
+// searchSpace encodes the parameters into the unit hypercube. The numerical
+// parameters are scaled to [0, 1] (in the log domain if needed), and the
+// categorical parameters are one-hot encoded.
+type searchSpace struct {
+	names         []string
+	distributions []interface{}
+	// columns holds the indices of the encoded columns of each parameter.
+	columns [][]int
+	// continuous holds whether each encoded column is numerical.
+	continuous []bool
+}
+
+func newSearchSpace(names []string, distributions map[string]interface{}) (*searchSpace, error) {
+	s := &searchSpace{
+		names:         names,
+		distributions: make([]interface{}, len(names)),
+		columns:       make([][]int, len(names)),
+	}
+	for i, name := range names {
+		s.distributions[i] = distributions[name]
+		if d, ok := distributions[name].(goptuna.CategoricalDistribution); ok {
+			for range d.Choices {
+				s.columns[i] = append(s.columns[i], len(s.continuous))
+				s.continuous = append(s.continuous, false)
+			}
+			continue
+		}
+		if _, ok := numerical.New(distributions[name]); !ok {
+			return nil, goptuna.ErrUnsupportedSearchSpace
+		}
+		s.columns[i] = append(s.columns[i], len(s.continuous))
+		s.continuous = append(s.continuous, true)
+	}
+	return s, nil
+}
+
+func (s *searchSpace) dim() int {
+	return len(s.continuous)
+}
+
+func (s *searchSpace) encode(params map[string]float64) ([]float64, bool) {
+	x := make([]float64, s.dim())
+	for i, name := range s.names {
+		ir, ok := params[name]
+		if !ok {
+			return nil, false
+		}
+		if d, ok := numerical.New(s.distributions[i]); ok {
+			x[s.columns[i][0]] = d.Normalize(ir)
+			continue
+		}
+		index := int(ir)
+		if index < 0 || index >= len(s.columns[i]) {
+			return nil, false
+		}
+		x[s.columns[i][index]] = 1
+	}
+	return x, true
+}
+
+func (s *searchSpace) decode(x []float64) map[string]float64 {
+	params := make(map[string]float64, len(s.names))
+	for i, name := range s.names {
+		if d, ok := numerical.New(s.distributions[i]); ok {
+			params[name] = d.Denormalize(x[s.columns[i][0]])
+			continue
+		}
+		best := 0
+		for j, c := range s.columns[i] {
+			if x[c] > x[s.columns[i][best]] {
+				best = j
+			}
+		}
+		params[name] = float64(best)
+	}
+	return params
+}
+
+// sample draws a point from the encoded search space uniformly at random.
+func (s *searchSpace) sample(rng *rand.Rand) []float64 {
+	x := make([]float64, s.dim())
+	for i := range s.names {
+		if len(s.columns[i]) == 1 && s.continuous[s.columns[i][0]] {
+			x[s.columns[i][0]] = rng.Float64()
+			continue
+		}
+		x[s.columns[i][rng.Intn(len(s.columns[i]))]] = 1
+	}
+	return x
+}
+
+// round snaps the encoded point onto the values which can be decoded exactly,
+// so that the acquisition function is evaluated at the point to be proposed.
+func (s *searchSpace) round(x []float64) []float64 {
+	rounded := make([]float64, len(x))
+	copy(rounded, x)
+	params := s.decode(x)
+	for i, name := range s.names {
+		d, ok := numerical.New(s.distributions[i])
+		if !ok || d.Step == 0 {
+			continue
+		}
+		rounded[s.columns[i][0]] = d.Normalize(params[name])
+	}
+	return rounded
+}
@@ -0,0 +1,108 @@
+package goptuna
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrHeartbeatNotSupported represents the storage does not implement HeartbeatStorage.
+var ErrHeartbeatNotSupported = errors.New("heartbeat is not supported by the storage")
+
+// HeartbeatStorage is an optional interface of Storage to detect the stale trials
+// which are left as TrialStateRunning by the dead worker processes.
+type HeartbeatStorage interface {
+	// RecordHeartbeat records the heartbeat of the running trial.
+	RecordHeartbeat(trialID int) error
+	// GetStaleTrialIDs returns IDs of the running trials whose latest heartbeat
+	// is older than the grace period.
+	GetStaleTrialIDs(studyID int, gracePeriod time.Duration) ([]int, error)
+}
+
+// heartbeatGracePeriodFactor is the multiple of the heartbeat interval to decide
+// the trial is stale.
+const heartbeatGracePeriodFactor = 2
+
+// FailStaleTrials changes the state of the stale trials to TrialStateFail.
+// The trial is stale if it is still running but its heartbeat is not
+// recorded within twice the interval of StudyOptionHeartbeatInterval.
+// This is called before each trial starts in Optimize, so you don't need to
+// call it unless you want to clean up the stale trials of the other workers.
+func (s *Study) FailStaleTrials() error {
+	if s.heartbeatInterval <= 0 {
+		return nil
+	}
+	storage, ok := s.Storage.(HeartbeatStorage)
+	if !ok {
+		return ErrHeartbeatNotSupported
+	}
+
+	gracePeriod := heartbeatGracePeriodFactor * s.heartbeatInterval
+	trialIDs, err := storage.GetStaleTrialIDs(s.ID, gracePeriod)
+	if err != nil {
+		return err
+	}
+	for _, trialID := range trialIDs {
+		reason := fmt.Sprintf("heartbeat is not recorded for %s", gracePeriod)
+		err = s.Storage.SetTrialSystemAttr(trialID, failReasonSystemAttrKey, reason)
+		if err == ErrTrialCannotBeUpdated {
+			// The trial is already finished by the other worker.
+			continue
+		} else if err != nil {
+			return err
+		}
+		err = s.Storage.SetTrialState(trialID, TrialStateFail)
+		if err == ErrTrialCannotBeUpdated {
+			continue
+		} else if err != nil {
+			return err
+		}
+		s.logger.Warn("Stale trial is failed",
+			fmt.Sprintf("trialID=%d", trialID))
+
+		if _, err = s.retryFailedTrial(trialID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// startHeartbeat records the heartbeat of the trial periodically until
+// the returned function is called.
+func (s *Study) startHeartbeat(trialID int) func() {
+	storage, ok := s.Storage.(HeartbeatStorage)
+	if s.heartbeatInterval <= 0 || !ok {
+		return func() {}
+	}
+
+	record := func() {
+		if err := storage.RecordHeartbeat(trialID); err != nil {
+			s.logger.Error("Failed to record heartbeat",
+				fmt.Sprintf("trialID=%d", trialID),
+				fmt.Sprintf("err=%s", err))
+		}
+	}
+	record()
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(s.heartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				record()
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		wg.Wait()
+	}
+}
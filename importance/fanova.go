@@ -0,0 +1,264 @@
+package importance
+
+import (
+	"math"
+	"sort"
+)
+
+// fanovaTree computes the marginal variances of the features on a regression tree.
+// See "An Efficient Approach for Assessing Hyperparameter Importance" by Hutter et al.
+type fanovaTree struct {
+	tree         *regressionTree
+	searchSpaces [][2]float64
+	// statistics holds the weighted average value and the sum of weights of each node.
+	statisticsValue  []float64
+	statisticsWeight []float64
+	splitMidpoints   [][]float64
+	splitSizes       [][]float64
+	// subtreeActiveFeatures holds whether the subtree of each node splits on each feature.
+	subtreeActiveFeatures [][]bool
+	variance              float64
+}
+
+func newFanovaTree(tree *regressionTree, searchSpaces [][2]float64) *fanovaTree {
+	t := &fanovaTree{
+		tree:         tree,
+		searchSpaces: searchSpaces,
+	}
+	t.precomputeStatistics()
+	t.precomputeSplitMidpointsAndSizes()
+	t.precomputeSubtreeActiveFeatures()
+
+	var values, weights []float64
+	for node := 0; node < tree.nNodes(); node++ {
+		if tree.isLeaf(node) {
+			values = append(values, t.statisticsValue[node])
+			weights = append(weights, t.statisticsWeight[node])
+		}
+	}
+	t.variance = weightedVariance(values, weights)
+	return t
+}
+
+func (t *fanovaTree) childSubspaces(node int, subspace [][2]float64) ([][2]float64, [][2]float64) {
+	feature := t.tree.feature[node]
+	threshold := t.tree.threshold[node]
+	left := make([][2]float64, len(subspace))
+	right := make([][2]float64, len(subspace))
+	copy(left, subspace)
+	copy(right, subspace)
+	left[feature][1] = threshold
+	right[feature][0] = threshold
+	return left, right
+}
+
+func (t *fanovaTree) precomputeStatistics() {
+	n := t.tree.nNodes()
+	t.statisticsValue = make([]float64, n)
+	t.statisticsWeight = make([]float64, n)
+
+	subspaces := make([][][2]float64, n)
+	subspaces[0] = t.searchSpaces
+	for node := 0; node < n; node++ {
+		if t.tree.isLeaf(node) {
+			t.statisticsValue[node] = t.tree.value[node]
+			t.statisticsWeight[node] = cardinality(subspaces[node])
+			continue
+		}
+		left, right := t.childSubspaces(node, subspaces[node])
+		subspaces[t.tree.left[node]] = left
+		subspaces[t.tree.right[node]] = right
+	}
+
+	// The children are always visited before the parent in reversed pre-order.
+	for node := n - 1; node >= 0; node-- {
+		if t.tree.isLeaf(node) {
+			continue
+		}
+		l, r := t.tree.left[node], t.tree.right[node]
+		t.statisticsValue[node] = weightedAverage(
+			[]float64{t.statisticsValue[l], t.statisticsValue[r]},
+			[]float64{t.statisticsWeight[l], t.statisticsWeight[r]})
+		t.statisticsWeight[node] = t.statisticsWeight[l] + t.statisticsWeight[r]
+	}
+}
+
+func (t *fanovaTree) precomputeSplitMidpointsAndSizes() {
+	nFeatures := len(t.searchSpaces)
+	splitValues := make([]map[float64]struct{}, nFeatures)
+	for f := range splitValues {
+		splitValues[f] = make(map[float64]struct{})
+	}
+	for node := 0; node < t.tree.nNodes(); node++ {
+		if !t.tree.isLeaf(node) {
+			splitValues[t.tree.feature[node]][t.tree.threshold[node]] = struct{}{}
+		}
+	}
+
+	t.splitMidpoints = make([][]float64, nFeatures)
+	t.splitSizes = make([][]float64, nFeatures)
+	for f := 0; f < nFeatures; f++ {
+		values := make([]float64, 0, len(splitValues[f])+2)
+		values = append(values, t.searchSpaces[f][0])
+		sorted := make([]float64, 0, len(splitValues[f]))
+		for v := range splitValues[f] {
+			sorted = append(sorted, v)
+		}
+		sort.Float64s(sorted)
+		values = append(values, sorted...)
+		values = append(values, t.searchSpaces[f][1])
+
+		for i := 1; i < len(values); i++ {
+			t.splitMidpoints[f] = append(t.splitMidpoints[f], (values[i]+values[i-1])/2)
+			t.splitSizes[f] = append(t.splitSizes[f], values[i]-values[i-1])
+		}
+	}
+}
+
+func (t *fanovaTree) precomputeSubtreeActiveFeatures() {
+	n := t.tree.nNodes()
+	nFeatures := len(t.searchSpaces)
+	t.subtreeActiveFeatures = make([][]bool, n)
+	for node := n - 1; node >= 0; node-- {
+		t.subtreeActiveFeatures[node] = make([]bool, nFeatures)
+		if t.tree.isLeaf(node) {
+			continue
+		}
+		t.subtreeActiveFeatures[node][t.tree.feature[node]] = true
+		for _, child := range []int{t.tree.left[node], t.tree.right[node]} {
+			for f := 0; f < nFeatures; f++ {
+				if t.subtreeActiveFeatures[child][f] {
+					t.subtreeActiveFeatures[node][f] = true
+				}
+			}
+		}
+	}
+}
+
+// marginalVariance returns the variance of the prediction marginalized over
+// all features except the given features.
+func (t *fanovaTree) marginalVariance(features []int) float64 {
+	sample := make([]float64, len(t.searchSpaces))
+	for f := range sample {
+		sample[f] = math.NaN()
+	}
+
+	var values, weights []float64
+	// Iterate over the cartesian product of the midpoints of the given features.
+	indices := make([]int, len(features))
+	for {
+		size := 1.0
+		for i, f := range features {
+			sample[f] = t.splitMidpoints[f][indices[i]]
+			size *= t.splitSizes[f][indices[i]]
+		}
+		value, weight := t.marginalizedStatistics(sample)
+		values = append(values, value)
+		weights = append(weights, weight*size)
+
+		i := 0
+		for ; i < len(features); i++ {
+			indices[i]++
+			if indices[i] < len(t.splitMidpoints[features[i]]) {
+				break
+			}
+			indices[i] = 0
+		}
+		if i == len(features) {
+			break
+		}
+	}
+	return weightedVariance(values, weights)
+}
+
+func (t *fanovaTree) marginalizedStatistics(sample []float64) (float64, float64) {
+	searchSpaces := make([][2]float64, len(t.searchSpaces))
+	copy(searchSpaces, t.searchSpaces)
+	active := make([]bool, len(sample))
+	for f := range sample {
+		if math.IsNaN(sample[f]) {
+			// Reduce the cardinalities of the marginalized features to 1.
+			searchSpaces[f] = [2]float64{0, 1}
+		} else {
+			active[f] = true
+		}
+	}
+
+	activeNodes := []int{0}
+	activeSearchSpaces := [][][2]float64{searchSpaces}
+	var values, weights []float64
+	for len(activeNodes) > 0 {
+		node := activeNodes[len(activeNodes)-1]
+		subspace := activeSearchSpaces[len(activeSearchSpaces)-1]
+		activeNodes = activeNodes[:len(activeNodes)-1]
+		activeSearchSpaces = activeSearchSpaces[:len(activeSearchSpaces)-1]
+
+		if !t.tree.isLeaf(node) {
+			feature := t.tree.feature[node]
+			if active[feature] {
+				// Push the child node which the sample ends up in.
+				left, right := t.childSubspaces(node, subspace)
+				if sample[feature] <= t.tree.threshold[node] {
+					activeNodes = append(activeNodes, t.tree.left[node])
+					activeSearchSpaces = append(activeSearchSpaces, left)
+				} else {
+					activeNodes = append(activeNodes, t.tree.right[node])
+					activeSearchSpaces = append(activeSearchSpaces, right)
+				}
+				continue
+			}
+			// Push both children if the subtree splits on any active feature.
+			splitsOnActive := false
+			for f := range active {
+				if active[f] && t.subtreeActiveFeatures[node][f] {
+					splitsOnActive = true
+					break
+				}
+			}
+			if splitsOnActive {
+				activeNodes = append(activeNodes, t.tree.left[node], t.tree.right[node])
+				activeSearchSpaces = append(activeSearchSpaces, subspace, subspace)
+				continue
+			}
+		}
+
+		// The node is a leaf or its subtree doesn't split on any active feature.
+		values = append(values, t.statisticsValue[node])
+		weights = append(weights, t.statisticsWeight[node]/cardinality(subspace))
+	}
+
+	var weightSum float64
+	for _, w := range weights {
+		weightSum += w
+	}
+	return weightedAverage(values, weights), weightSum
+}
+
+func cardinality(searchSpaces [][2]float64) float64 {
+	c := 1.0
+	for _, s := range searchSpaces {
+		c *= s[1] - s[0]
+	}
+	return c
+}
+
+func weightedAverage(values, weights []float64) float64 {
+	var sum, weightSum float64
+	for i := range values {
+		sum += values[i] * weights[i]
+		weightSum += weights[i]
+	}
+	if weightSum == 0 {
+		return 0
+	}
+	return sum / weightSum
+}
+
+func weightedVariance(values, weights []float64) float64 {
+	mean := weightedAverage(values, weights)
+	squared := make([]float64, len(values))
+	for i := range values {
+		squared[i] = (values[i] - mean) * (values[i] - mean)
+	}
+	return weightedAverage(squared, weights)
+}
@@ -0,0 +1,35 @@
+package importance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFanovaTree(t *testing.T) {
+	// f(x0, x1) = 2 if x0 > 0.5 else (1 if x1 > 0.5 else 0)
+	tree := &regressionTree{
+		feature:   []int{0, 1, -1, -1, -1},
+		threshold: []float64{0.5, 0.5, 0, 0, 0},
+		left:      []int{1, 2, -1, -1, -1},
+		right:     []int{4, 3, -1, -1, -1},
+		value:     []float64{0, 0, 0, 1, 2},
+	}
+	ft := newFanovaTree(tree, [][2]float64{{0, 1}, {0, 1}})
+
+	// values: 0 (25%), 1 (25%), 2 (50%) -> mean 1.25
+	expectedVariance := 0.25*1.25*1.25 + 0.25*0.25*0.25 + 0.5*0.75*0.75
+	if math.Abs(ft.variance-expectedVariance) > 1e-9 {
+		t.Errorf("variance should be %f, but got %f", expectedVariance, ft.variance)
+	}
+	// marginal of x0: 0.5 (x0 <= 0.5), 2 (x0 > 0.5) -> variance 0.5625
+	if v := ft.marginalVariance([]int{0}); math.Abs(v-0.5625) > 1e-9 {
+		t.Errorf("marginal variance of x0 should be 0.5625, but got %f", v)
+	}
+	// marginal of x1: 1 (x1 <= 0.5), 1.5 (x1 > 0.5) -> variance 0.0625
+	if v := ft.marginalVariance([]int{1}); math.Abs(v-0.0625) > 1e-9 {
+		t.Errorf("marginal variance of x1 should be 0.0625, but got %f", v)
+	}
+	if v := ft.marginalVariance([]int{0, 1}); math.Abs(v-expectedVariance) > 1e-9 {
+		t.Errorf("marginal variance of all features should be %f, but got %f", expectedVariance, v)
+	}
+}
@@ -0,0 +1,154 @@
+package importance
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// regressionTree is a binary tree fitted by CART. The nodes are stored in
+// pre-order, so the indices of the children are always larger than the parent.
+type regressionTree struct {
+	feature   []int // -1 for the leaf nodes
+	threshold []float64
+	left      []int
+	right     []int
+	value     []float64
+}
+
+func (t *regressionTree) isLeaf(node int) bool {
+	return t.feature[node] < 0
+}
+
+func (t *regressionTree) nNodes() int {
+	return len(t.feature)
+}
+
+type treeBuilder struct {
+	x        [][]float64
+	y        []float64
+	maxDepth int
+	rng      *rand.Rand
+	tree     *regressionTree
+}
+
+func (b *treeBuilder) addNode(value float64) int {
+	b.tree.feature = append(b.tree.feature, -1)
+	b.tree.threshold = append(b.tree.threshold, 0)
+	b.tree.left = append(b.tree.left, -1)
+	b.tree.right = append(b.tree.right, -1)
+	b.tree.value = append(b.tree.value, value)
+	return len(b.tree.feature) - 1
+}
+
+func (b *treeBuilder) build(samples []int, depth int) int {
+	var sum float64
+	for _, i := range samples {
+		sum += b.y[i]
+	}
+	mean := sum / float64(len(samples))
+	node := b.addNode(mean)
+	if len(samples) < 2 || depth >= b.maxDepth {
+		return node
+	}
+
+	var sse float64
+	for _, i := range samples {
+		sse += (b.y[i] - mean) * (b.y[i] - mean)
+	}
+	if sse <= 1e-12*float64(len(samples)) {
+		// The node is pure.
+		return node
+	}
+
+	feature, threshold, ok := b.bestSplit(samples)
+	if !ok {
+		return node
+	}
+	left := make([]int, 0, len(samples))
+	right := make([]int, 0, len(samples))
+	for _, i := range samples {
+		if b.x[i][feature] <= threshold {
+			left = append(left, i)
+		} else {
+			right = append(right, i)
+		}
+	}
+
+	b.tree.feature[node] = feature
+	b.tree.threshold[node] = threshold
+	b.tree.left[node] = b.build(left, depth+1)
+	b.tree.right[node] = b.build(right, depth+1)
+	return node
+}
+
+// bestSplit returns the split which minimizes the sum of squared errors.
+func (b *treeBuilder) bestSplit(samples []int) (int, float64, bool) {
+	nFeatures := len(b.x[0])
+	sorted := make([]int, len(samples))
+	bestFeature, bestThreshold := -1, 0.0
+	bestSSE := math.Inf(1)
+
+	// Features are visited in random order to break ties randomly.
+	for _, f := range b.rng.Perm(nFeatures) {
+		copy(sorted, samples)
+		sort.Slice(sorted, func(i, j int) bool {
+			return b.x[sorted[i]][f] < b.x[sorted[j]][f]
+		})
+
+		var totalSum, totalSquaredSum float64
+		for _, i := range sorted {
+			totalSum += b.y[i]
+			totalSquaredSum += b.y[i] * b.y[i]
+		}
+		var leftSum, leftSquaredSum float64
+		n := float64(len(sorted))
+		for k := 1; k < len(sorted); k++ {
+			yi := b.y[sorted[k-1]]
+			leftSum += yi
+			leftSquaredSum += yi * yi
+
+			lower := b.x[sorted[k-1]][f]
+			upper := b.x[sorted[k]][f]
+			if lower >= upper {
+				continue
+			}
+			nLeft := float64(k)
+			rightSum := totalSum - leftSum
+			rightSquaredSum := totalSquaredSum - leftSquaredSum
+			sse := leftSquaredSum - leftSum*leftSum/nLeft +
+				rightSquaredSum - rightSum*rightSum/(n-nLeft)
+			if sse < bestSSE {
+				bestSSE = sse
+				bestFeature = f
+				bestThreshold = (lower + upper) / 2
+				if bestThreshold >= upper {
+					bestThreshold = lower
+				}
+			}
+		}
+	}
+	return bestFeature, bestThreshold, bestFeature >= 0
+}
+
+// fitForest fits the random forest regressor. Each tree is fitted to the
+// bootstrap samples of the training data.
+func fitForest(x [][]float64, y []float64, nTrees, maxDepth int, rng *rand.Rand) []*regressionTree {
+	trees := make([]*regressionTree, nTrees)
+	for t := 0; t < nTrees; t++ {
+		samples := make([]int, len(y))
+		for i := range samples {
+			samples[i] = rng.Intn(len(y))
+		}
+		builder := &treeBuilder{
+			x:        x,
+			y:        y,
+			maxDepth: maxDepth,
+			rng:      rng,
+			tree:     &regressionTree{},
+		}
+		builder.build(samples, 0)
+		trees[t] = builder.tree
+	}
+	return trees
+}
@@ -0,0 +1,244 @@
+// Package importance evaluates the importances of the hyperparameters.
+//
+// FanovaEvaluator fits a random forest regression model to the completed trials,
+// then computes the fraction of the variance of the objective values explained by
+// each parameter, which is known as fANOVA. The importances are normalized to sum to 1.
+// See "An Efficient Approach for Assessing Hyperparameter Importance" by Hutter et al.
+package importance
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/c-bata/goptuna"
+)
+
+var (
+	// ErrZeroTotalVariance represents the objective values don't vary, so the
+	// importances cannot be evaluated.
+	ErrZeroTotalVariance = errors.New("encountered zero total variance in all trees")
+)
+
+// GetParamImportances evaluates the importances of the parameters by fANOVA
+// with the default options.
+func GetParamImportances(study *goptuna.Study) (map[string]float64, error) {
+	return NewFanovaEvaluator().Evaluate(study)
+}
+
+// FanovaEvaluator evaluates the importances of the parameters by fANOVA.
+type FanovaEvaluator struct {
+	nTrees   int
+	maxDepth int
+	seed     int64
+	params   []string
+	target   func(trial goptuna.FrozenTrial) float64
+}
+
+// NewFanovaEvaluator returns a new FanovaEvaluator.
+func NewFanovaEvaluator(opts ...FanovaOption) *FanovaEvaluator {
+	evaluator := &FanovaEvaluator{
+		nTrees:   64,
+		maxDepth: 64,
+		seed:     0,
+	}
+	for _, opt := range opts {
+		opt(evaluator)
+	}
+	return evaluator
+}
+
+// Evaluate returns the importances of the parameters in the intersection search
+// space of the completed trials. The parameters whose distributions contain just
+// a single value are excluded.
+func (e *FanovaEvaluator) Evaluate(study *goptuna.Study) (map[string]float64, error) {
+	if study.IsMultiObjective() && e.target == nil {
+		return nil, goptuna.ErrMultiObjectiveStudy
+	}
+	target := e.target
+	if target == nil {
+		target = func(trial goptuna.FrozenTrial) float64 {
+			return trial.Value
+		}
+	}
+
+	searchSpace, err := goptuna.IntersectionSearchSpace(study)
+	if err != nil {
+		return nil, err
+	}
+	names, err := e.paramNames(searchSpace)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return map[string]float64{}, nil
+	}
+
+	trials, err := study.GetTrials()
+	if err != nil && err != goptuna.ErrTrialsPartiallyDeleted {
+		return nil, err
+	}
+	var x [][]float64
+	var y []float64
+	for i := range trials {
+		if trials[i].State != goptuna.TrialStateComplete {
+			continue
+		}
+		value := target(trials[i])
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			continue
+		}
+		row, err := encodeParams(trials[i], names, searchSpace)
+		if err != nil {
+			return nil, err
+		}
+		x = append(x, row)
+		y = append(y, value)
+	}
+	if len(y) == 0 {
+		return nil, goptuna.ErrNoCompletedTrials
+	}
+
+	searchSpaces, columns, err := encodeSearchSpace(names, searchSpace)
+	if err != nil {
+		return nil, err
+	}
+	rng := rand.New(rand.NewSource(e.seed))
+	forest := fitForest(x, y, e.nTrees, e.maxDepth, rng)
+
+	trees := make([]*fanovaTree, 0, len(forest))
+	for _, tree := range forest {
+		if t := newFanovaTree(tree, searchSpaces); t.variance > 0 {
+			trees = append(trees, t)
+		}
+	}
+	if len(trees) == 0 {
+		return nil, ErrZeroTotalVariance
+	}
+
+	importances := make(map[string]float64, len(names))
+	var total float64
+	for i, name := range names {
+		var sum float64
+		for _, t := range trees {
+			v := math.Max(t.marginalVariance(columns[i]), 0)
+			sum += v / t.variance
+		}
+		importances[name] = sum / float64(len(trees))
+		total += importances[name]
+	}
+	if total > 0 {
+		for name := range importances {
+			importances[name] /= total
+		}
+	}
+	return importances, nil
+}
+
+func (e *FanovaEvaluator) paramNames(searchSpace map[string]interface{}) ([]string, error) {
+	names := e.params
+	if names == nil {
+		names = make([]string, 0, len(searchSpace))
+		for name := range searchSpace {
+			names = append(names, name)
+		}
+	}
+
+	filtered := make([]string, 0, len(names))
+	for _, name := range names {
+		d, ok := searchSpace[name]
+		if !ok {
+			return nil, fmt.Errorf("parameter '%s' is not in the intersection search space", name)
+		}
+		single, err := goptuna.DistributionIsSingle(d)
+		if err != nil {
+			return nil, err
+		}
+		if !single {
+			filtered = append(filtered, name)
+		}
+	}
+	sort.Strings(filtered)
+	return filtered, nil
+}
+
+// encodeSearchSpace returns the bounds of the encoded columns and the indices of
+// the columns of each parameter. The log-scaled parameters are encoded in the
+// log domain, and the categorical parameters are one-hot encoded.
+func encodeSearchSpace(names []string, searchSpace map[string]interface{}) ([][2]float64, [][]int, error) {
+	var bounds [][2]float64
+	columns := make([][]int, len(names))
+	for i, name := range names {
+		switch d := searchSpace[name].(type) {
+		case goptuna.CategoricalDistribution:
+			for range d.Choices {
+				columns[i] = append(columns[i], len(bounds))
+				bounds = append(bounds, [2]float64{0, 1})
+			}
+			continue
+		default:
+			low, high, log, err := numericalBounds(d)
+			if err != nil {
+				return nil, nil, err
+			}
+			if log {
+				low, high = math.Log(low), math.Log(high)
+			}
+			columns[i] = append(columns[i], len(bounds))
+			bounds = append(bounds, [2]float64{low, high})
+		}
+	}
+	return bounds, columns, nil
+}
+
+func encodeParams(trial goptuna.FrozenTrial, names []string, searchSpace map[string]interface{}) ([]float64, error) {
+	var row []float64
+	for _, name := range names {
+		ir, ok := trial.InternalParams[name]
+		if !ok {
+			return nil, fmt.Errorf("parameter '%s' is not found in trial %d", name, trial.Number)
+		}
+		switch d := searchSpace[name].(type) {
+		case goptuna.CategoricalDistribution:
+			for j := range d.Choices {
+				if j == int(ir) {
+					row = append(row, 1)
+				} else {
+					row = append(row, 0)
+				}
+			}
+		default:
+			_, _, log, err := numericalBounds(d)
+			if err != nil {
+				return nil, err
+			}
+			if log {
+				ir = math.Log(ir)
+			}
+			row = append(row, ir)
+		}
+	}
+	return row, nil
+}
+
+func numericalBounds(distribution interface{}) (low, high float64, log bool, err error) {
+	switch d := distribution.(type) {
+	case goptuna.UniformDistribution:
+		return d.Low, d.High, false, nil
+	case goptuna.LogUniformDistribution:
+		return d.Low, d.High, true, nil
+	case goptuna.DiscreteUniformDistribution:
+		return d.Low, d.High, false, nil
+	case goptuna.FloatDistribution:
+		return d.Low, d.High, d.Log, nil
+	case goptuna.IntUniformDistribution:
+		return float64(d.Low), float64(d.High), false, nil
+	case goptuna.StepIntUniformDistribution:
+		return float64(d.Low), float64(d.High), false, nil
+	case goptuna.IntDistribution:
+		return float64(d.Low), float64(d.High), d.Log, nil
+	}
+	return 0, 0, false, goptuna.ErrUnsupportedSearchSpace
+}
@@ -0,0 +1,106 @@
+package importance_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/c-bata/goptuna"
+	"github.com/c-bata/goptuna/importance"
+)
+
+func TestFanovaEvaluator(t *testing.T) {
+	tests := []struct {
+		name      string
+		objective goptuna.FuncObjective
+		param     string
+		min       float64
+		max       float64
+	}{
+		{
+			name: "numerical",
+			objective: func(trial goptuna.Trial) (float64, error) {
+				x1, _ := trial.SuggestFloat("x1", 0, 1)
+				x2, _ := trial.SuggestInt("x2", 0, 10)
+				return 10*x1 + 0.1*float64(x2), nil
+			},
+			param: "x1",
+			min:   0.9,
+			max:   1.0,
+		},
+		{
+			name: "categorical",
+			objective: func(trial goptuna.Trial) (float64, error) {
+				x, _ := trial.SuggestFloat("x", 0, 1)
+				c, _ := trial.SuggestCategorical("c", []string{"a", "b", "c"})
+				if c == "b" {
+					return 10 + x, nil
+				}
+				return x, nil
+			},
+			param: "c",
+			min:   0.9,
+			max:   1.0,
+		},
+		{
+			// The variances are 0.16 and 0.25 in the log domain, so the importance
+			// of 'lr' is about 0.39. It is about 0.26 if 'lr' is not log-scaled.
+			name: "log",
+			objective: func(trial goptuna.Trial) (float64, error) {
+				lr, _ := trial.SuggestLogFloat("lr", 1e-5, 1)
+				z, _ := trial.SuggestFloat("z", 0, 1)
+				var v float64
+				if lr > 0.1 {
+					v++
+				}
+				if z > 0.5 {
+					v++
+				}
+				return v, nil
+			},
+			param: "lr",
+			min:   0.33,
+			max:   0.45,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			study, _ := goptuna.CreateStudy("example",
+				goptuna.StudyOptionSampler(goptuna.NewRandomSampler(goptuna.RandomSamplerOptionSeed(1))),
+				goptuna.StudyOptionLogger(nil))
+			if err := study.Optimize(tt.objective, 300); err != nil {
+				t.Errorf("err: %v != nil", err)
+				return
+			}
+			importances, err := importance.NewFanovaEvaluator(
+				importance.FanovaOptionNTrees(16)).Evaluate(study)
+			if err != nil {
+				t.Errorf("err: %v != nil", err)
+				return
+			}
+			var sum float64
+			for name := range importances {
+				sum += importances[name]
+			}
+			if math.Abs(sum-1) > 1e-9 {
+				t.Errorf("importances should sum to 1, but got %v", importances)
+			}
+			if v := importances[tt.param]; v < tt.min || v > tt.max {
+				t.Errorf("importance of %s should be in [%f, %f], but got %v",
+					tt.param, tt.min, tt.max, importances)
+			}
+		})
+	}
+}
+
+func TestFanovaEvaluator_ZeroTotalVariance(t *testing.T) {
+	study, _ := goptuna.CreateStudy("example", goptuna.StudyOptionLogger(nil))
+	_ = study.Optimize(func(trial goptuna.Trial) (float64, error) {
+		_, _ = trial.SuggestFloat("x", 0, 1)
+		return 1, nil
+	}, 10)
+	_, err := importance.GetParamImportances(study)
+	if err != importance.ErrZeroTotalVariance {
+		t.Errorf("should be ErrZeroTotalVariance, but got %v", err)
+	}
+}
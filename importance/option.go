@@ -0,0 +1,42 @@
+package importance
+
+import "github.com/c-bata/goptuna"
+
+// FanovaOption is a type of function to customize FanovaEvaluator.
+type FanovaOption func(evaluator *FanovaEvaluator)
+
+// FanovaOptionNTrees sets the number of trees in the random forest.
+func FanovaOptionNTrees(nTrees int) FanovaOption {
+	return func(evaluator *FanovaEvaluator) {
+		evaluator.nTrees = nTrees
+	}
+}
+
+// FanovaOptionMaxDepth sets the maximum depth of the trees in the random forest.
+func FanovaOptionMaxDepth(maxDepth int) FanovaOption {
+	return func(evaluator *FanovaEvaluator) {
+		evaluator.maxDepth = maxDepth
+	}
+}
+
+// FanovaOptionSeed sets the seed of the random forest.
+func FanovaOptionSeed(seed int64) FanovaOption {
+	return func(evaluator *FanovaEvaluator) {
+		evaluator.seed = seed
+	}
+}
+
+// FanovaOptionParams evaluates the importances of only the given parameters.
+func FanovaOptionParams(params ...string) FanovaOption {
+	return func(evaluator *FanovaEvaluator) {
+		evaluator.params = params
+	}
+}
+
+// FanovaOptionTarget sets the function to get the value to evaluate the importances.
+// This is required for multi-objective studies.
+func FanovaOptionTarget(target func(trial goptuna.FrozenTrial) float64) FanovaOption {
+	return func(evaluator *FanovaEvaluator) {
+		evaluator.target = target
+	}
+}
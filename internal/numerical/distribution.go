@@ -0,0 +1,89 @@
+package numerical
+
+import (
+	"math"
+
+	"github.com/c-bata/goptuna"
+)
+
+// Distribution is a unified representation of the numerical distributions
+// to normalize the internal representations into [0, 1].
+type Distribution struct {
+	Low  float64
+	High float64
+	// Step is zero for the continuous distributions.
+	Step float64
+	Log  bool
+}
+
+// New returns the unified representation of the numerical distribution.
+// It returns false if the distribution is not numerical.
+func New(distribution interface{}) (Distribution, bool) {
+	switch d := distribution.(type) {
+	case goptuna.UniformDistribution:
+		return Distribution{Low: d.Low, High: d.High}, true
+	case goptuna.LogUniformDistribution:
+		return Distribution{Low: d.Low, High: d.High, Log: true}, true
+	case goptuna.DiscreteUniformDistribution:
+		return Distribution{Low: d.Low, High: d.High, Step: d.Q}, true
+	case goptuna.FloatDistribution:
+		return Distribution{Low: d.Low, High: d.High, Step: d.Step, Log: d.Log}, true
+	case goptuna.IntUniformDistribution:
+		return Distribution{Low: float64(d.Low), High: float64(d.High), Step: 1}, true
+	case goptuna.StepIntUniformDistribution:
+		// The high endpoint is excluded from the range of StepIntUniformDistribution.
+		high := d.Low + (d.High-d.Low-1)/d.Step*d.Step
+		return Distribution{Low: float64(d.Low), High: float64(high), Step: float64(d.Step)}, true
+	case goptuna.IntDistribution:
+		step := d.Step
+		if step == 0 {
+			step = 1
+		}
+		return Distribution{Low: float64(d.Low), High: float64(d.High), Step: float64(step), Log: d.Log}, true
+	}
+	return Distribution{}, false
+}
+
+// bounds returns the range of the transformed values. The range of the discrete
+// distributions is extended by half a step to give the same width to each value.
+func (d Distribution) bounds() (float64, float64) {
+	low, high := d.Low, d.High
+	if d.Step > 0 {
+		low -= 0.5 * d.Step
+		high += 0.5 * d.Step
+	}
+	if d.Log {
+		return math.Log(low), math.Log(high)
+	}
+	return low, high
+}
+
+// Normalize scales the internal representation into [0, 1] (in the log domain if needed).
+func (d Distribution) Normalize(ir float64) float64 {
+	low, high := d.bounds()
+	if d.Log {
+		ir = math.Log(ir)
+	}
+	return (ir - low) / (high - low)
+}
+
+// Denormalize returns the internal representation of the normalized value.
+// The result is snapped onto the steps and clipped into the range.
+func (d Distribution) Denormalize(x float64) float64 {
+	low, high := d.bounds()
+	v := low + x*(high-low)
+	if d.Log {
+		v = math.Exp(v)
+	}
+	return d.Snap(v)
+}
+
+// Snap returns the nearest value on the steps within the range.
+func (d Distribution) Snap(v float64) float64 {
+	if d.Step > 0 {
+		n := math.Floor((d.High - d.Low) / d.Step)
+		k := math.Min(math.Max(math.Round((v-d.Low)/d.Step), 0), n)
+		return d.Low + k*d.Step
+	}
+	return math.Min(math.Max(v, d.Low), d.High)
+}
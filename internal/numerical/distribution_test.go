@@ -0,0 +1,70 @@
+package numerical_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/c-bata/goptuna"
+	"github.com/c-bata/goptuna/internal/numerical"
+)
+
+func TestDistribution_Normalize(t *testing.T) {
+	tests := []struct {
+		name         string
+		distribution goptuna.Distribution
+		irs          []float64
+	}{
+		{
+			name:         "uniform",
+			distribution: goptuna.UniformDistribution{Low: -1, High: 1},
+			irs:          []float64{-1, 0, 0.5, 1},
+		},
+		{
+			name:         "log uniform",
+			distribution: goptuna.LogUniformDistribution{Low: 1e-5, High: 1},
+			irs:          []float64{1e-5, 1e-3, 1},
+		},
+		{
+			name:         "float with step",
+			distribution: goptuna.FloatDistribution{Low: 0, High: 1, Step: 0.25},
+			irs:          []float64{0, 0.25, 1},
+		},
+		{
+			name:         "int",
+			distribution: goptuna.IntDistribution{Low: 1, High: 100, Log: true},
+			irs:          []float64{1, 7, 100},
+		},
+		{
+			name:         "step int uniform",
+			distribution: goptuna.StepIntUniformDistribution{Low: 0, High: 10, Step: 5},
+			irs:          []float64{0, 5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, ok := numerical.New(tt.distribution)
+			if !ok {
+				t.Errorf("%#v should be numerical", tt.distribution)
+				return
+			}
+			for _, ir := range tt.irs {
+				x := d.Normalize(ir)
+				if x < 0 || x > 1 {
+					t.Errorf("Normalize(%f) = %f, want in [0, 1]", ir, x)
+				}
+				if got := d.Denormalize(x); math.Abs(got-ir) > 1e-9 {
+					t.Errorf("Denormalize(Normalize(%f)) = %f", ir, got)
+				}
+			}
+			for _, x := range []float64{-0.5, 0, 1, 1.5} {
+				if ir := d.Denormalize(x); !tt.distribution.Contains(ir) {
+					t.Errorf("Denormalize(%f) = %f is not contained in %#v", x, ir, tt.distribution)
+				}
+			}
+		})
+	}
+
+	if _, ok := numerical.New(goptuna.CategoricalDistribution{Choices: []interface{}{"a"}}); ok {
+		t.Errorf("categorical distribution should not be numerical")
+	}
+}
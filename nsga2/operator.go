@@ -0,0 +1,111 @@
+package nsga2
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Crossover is the interface of the crossover operators for the numerical parameters.
+// The parameters of the parents are normalized into [0, 1] (in the log domain if needed),
+// and the child is clipped into [0, 1] after the crossover.
+// The categorical parameters are always inherited from either of the parents at random.
+type Crossover interface {
+	// Crossover returns the normalized parameter of the child.
+	Crossover(x0, x1 float64, rng *rand.Rand) float64
+}
+
+// Mutation is the interface of the mutation operators for the numerical parameters.
+// The parameter is normalized into [0, 1] (in the log domain if needed),
+// and the mutated parameter is clipped into [0, 1].
+// The categorical parameters are always mutated to a choice drawn at random.
+type Mutation interface {
+	// Mutate returns the mutated normalized parameter.
+	Mutate(x float64, rng *rand.Rand) float64
+}
+
+var (
+	_ Crossover = UniformCrossover{}
+	_ Crossover = BLXAlphaCrossover{}
+	_ Crossover = SBXCrossover{}
+	_ Mutation  = UniformMutation{}
+	_ Mutation  = PolynomialMutation{}
+)
+
+// UniformCrossover inherits the parameter from the second parent with the probability
+// of SwappingProb, and from the first parent otherwise.
+type UniformCrossover struct {
+	SwappingProb float64
+}
+
+// Crossover returns the normalized parameter of the child.
+func (c UniformCrossover) Crossover(x0, x1 float64, rng *rand.Rand) float64 {
+	if rng.Float64() < c.SwappingProb {
+		return x1
+	}
+	return x0
+}
+
+// BLXAlphaCrossover samples the child uniformly from the interval spanned by the parents,
+// which is extended by Alpha times its width on both sides.
+// See "Real-Coded Genetic Algorithms and Interval-Schemata" by Eshelman and Schaffer.
+type BLXAlphaCrossover struct {
+	Alpha float64
+}
+
+// Crossover returns the normalized parameter of the child.
+func (c BLXAlphaCrossover) Crossover(x0, x1 float64, rng *rand.Rand) float64 {
+	low, high := math.Min(x0, x1), math.Max(x0, x1)
+	d := high - low
+	low -= c.Alpha * d
+	high += c.Alpha * d
+	return low + rng.Float64()*(high-low)
+}
+
+// SBXCrossover is the simulated binary crossover. The larger Eta generates the
+// children closer to the parents.
+// See "Simulated Binary Crossover for Continuous Search Space" by Deb and Agrawal.
+type SBXCrossover struct {
+	Eta float64
+}
+
+// Crossover returns the normalized parameter of the child.
+func (c SBXCrossover) Crossover(x0, x1 float64, rng *rand.Rand) float64 {
+	u := rng.Float64()
+	var beta float64
+	if u <= 0.5 {
+		beta = math.Pow(2*u, 1/(c.Eta+1))
+	} else {
+		beta = math.Pow(1/(2*(1-u)), 1/(c.Eta+1))
+	}
+	if rng.Float64() < 0.5 {
+		return 0.5 * ((1+beta)*x0 + (1-beta)*x1)
+	}
+	return 0.5 * ((1-beta)*x0 + (1+beta)*x1)
+}
+
+// UniformMutation draws the parameter uniformly at random.
+type UniformMutation struct{}
+
+// Mutate returns the mutated normalized parameter.
+func (m UniformMutation) Mutate(x float64, rng *rand.Rand) float64 {
+	return rng.Float64()
+}
+
+// PolynomialMutation perturbs the parameter by the polynomial distribution.
+// The larger Eta generates the parameter closer to the original one.
+// See "A Combined Genetic Adaptive Search (GeneAS) for Engineering Design" by Deb and Goyal.
+type PolynomialMutation struct {
+	Eta float64
+}
+
+// Mutate returns the mutated normalized parameter.
+func (m PolynomialMutation) Mutate(x float64, rng *rand.Rand) float64 {
+	u := rng.Float64()
+	var delta float64
+	if u < 0.5 {
+		delta = math.Pow(2*u, 1/(m.Eta+1)) - 1
+	} else {
+		delta = 1 - math.Pow(2*(1-u), 1/(m.Eta+1))
+	}
+	return x + delta
+}
@@ -0,0 +1,192 @@
+package nsga2
+
+import (
+	"crypto/sha256"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/c-bata/goptuna"
+)
+
+func TestPopulationCacheKey(t *testing.T) {
+	keys := func(generations ...[]int) []string {
+		hasher := sha256.New()
+		var result []string
+		for _, numbers := range generations {
+			running := make([]goptuna.FrozenTrial, len(numbers))
+			for i := range numbers {
+				running[i].Number = numbers[i]
+			}
+			result = append(result, populationCacheKey(hasher, running))
+		}
+		return result
+	}
+
+	tests := []struct {
+		name string
+		a    [][]int
+		b    [][]int
+	}{
+		{name: "concatenated numbers", a: [][]int{{1}, {23}}, b: [][]int{{12}, {3}}},
+		{name: "numbers in a generation", a: [][]int{{1, 23}}, b: [][]int{{12, 3}}},
+		{name: "moved to the next generation", a: [][]int{{1}, {}}, b: [][]int{{}, {1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := keys(tt.a...), keys(tt.b...)
+			if a[len(a)-1] == b[len(b)-1] {
+				t.Errorf("keys should be different, but got %s", a[len(a)-1])
+			}
+		})
+	}
+}
+
+func addTrial(study *goptuna.Study, generation int, state goptuna.TrialState, values []float64) (int, error) {
+	trialID, err := study.Storage.CreateNewTrial(study.ID)
+	if err != nil {
+		return -1, err
+	}
+	err = study.Storage.SetTrialSystemAttr(trialID, generationKey, strconv.Itoa(generation))
+	if err != nil {
+		return -1, err
+	}
+	if state == goptuna.TrialStateRunning {
+		return trialID, nil
+	}
+	if err = study.Storage.SetTrialValues(trialID, values); err != nil {
+		return -1, err
+	}
+	return trialID, study.Storage.SetTrialState(trialID, state)
+}
+
+func TestSampler_CollectParentPopulation(t *testing.T) {
+	study, err := goptuna.CreateStudy("nsga2",
+		goptuna.StudyOptionDirections([]goptuna.StudyDirection{
+			goptuna.StudyDirectionMinimize,
+			goptuna.StudyDirectionMinimize,
+		}),
+		goptuna.StudyOptionLogger(nil),
+	)
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+
+	// The first generation is filled while a trial is still running.
+	for i := 0; i < 5; i++ {
+		if _, err = addTrial(study, 0, goptuna.TrialStateComplete, []float64{float64(i), float64(10 - i)}); err != nil {
+			t.Errorf("should be nil, but got %s", err)
+			return
+		}
+	}
+	runningID, err := addTrial(study, 0, goptuna.TrialStateRunning, nil)
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	for i := 0; i < 4; i++ {
+		if _, err = addTrial(study, 1, goptuna.TrialStateComplete, []float64{float64(i) + 1, float64(11 - i)}); err != nil {
+			t.Errorf("should be nil, but got %s", err)
+			return
+		}
+	}
+
+	collect := func(sampler *Sampler) (int, []int) {
+		generation, population, err := sampler.collectParentPopulation(study)
+		if err != nil {
+			t.Errorf("should be nil, but got %s", err)
+			return 0, nil
+		}
+		ids := make([]int, len(population))
+		for i := range population {
+			ids[i] = population[i].ID
+		}
+		return generation, ids
+	}
+
+	// Workers with the different seeds agree on the parent population,
+	// and the second worker uses the cache stored by the first one.
+	generation0, parents0 := collect(NewSampler(SamplerOptionPopulationSize(4), SamplerOptionSeed(0)))
+	attrs, err := study.GetSystemAttrs()
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	if len(attrs) != 1 {
+		t.Errorf("only the population of the second generation should be cached, but got %v", attrs)
+	}
+	generation1, parents1 := collect(NewSampler(SamplerOptionPopulationSize(4), SamplerOptionSeed(1)))
+	if generation0 != 1 || generation1 != 1 {
+		t.Errorf("parent generations should be 1, but got %d and %d", generation0, generation1)
+	}
+	if !reflect.DeepEqual(parents0, parents1) {
+		t.Errorf("parent populations should be the same, but got %v and %v", parents0, parents1)
+	}
+
+	// The running trial dominates all others after it's completed,
+	// so the stale cache must not be used.
+	if err = study.Storage.SetTrialValues(runningID, []float64{-1, -1}); err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	if err = study.Storage.SetTrialState(runningID, goptuna.TrialStateComplete); err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	_, parents := collect(NewSampler(SamplerOptionPopulationSize(4), SamplerOptionSeed(2)))
+	found := false
+	for _, id := range parents {
+		if id == runningID {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("parent population %v should contain the trial %d", parents, runningID)
+	}
+}
+
+func TestSampler_CollectParentPopulation_NJobs(t *testing.T) {
+	sampler := NewSampler(SamplerOptionPopulationSize(6))
+	study, err := goptuna.CreateStudy("nsga2",
+		goptuna.StudyOptionDirections([]goptuna.StudyDirection{
+			goptuna.StudyDirectionMinimize,
+			goptuna.StudyDirectionMinimize,
+		}),
+		goptuna.StudyOptionRelativeSampler(sampler),
+		goptuna.StudyOptionNJobs(4),
+		goptuna.StudyOptionLogger(nil),
+	)
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	err = study.OptimizeMulti(func(trial goptuna.Trial) ([]float64, error) {
+		x, _ := trial.SuggestFloat("x", 0, 5)
+		y, _ := trial.SuggestFloat("y", 0, 3)
+		return []float64{4*x*x + 4*y*y, (x-5)*(x-5) + (y-5)*(y-5)}, nil
+	}, 40)
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+
+	wantGeneration, wantPopulation, err := sampler.collectParentPopulation(study)
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	for seed := int64(1); seed <= 3; seed++ {
+		// Another worker which shares the storage.
+		worker := NewSampler(SamplerOptionPopulationSize(6), SamplerOptionSeed(seed))
+		generation, population, err := worker.collectParentPopulation(study)
+		if err != nil {
+			t.Errorf("should be nil, but got %s", err)
+			return
+		}
+		if generation != wantGeneration || !reflect.DeepEqual(population, wantPopulation) {
+			t.Errorf("worker %d should select the same parents of generation %d, but got generation %d",
+				seed, wantGeneration, generation)
+		}
+	}
+}
@@ -0,0 +1,326 @@
+// Package nsga2 provides a sampler based on NSGA-II, the elitist non-dominated sorting
+// genetic algorithm for the multi-objective optimization.
+//
+// Each trial belongs to a generation, which is stored in the system attributes of the
+// trial. Once the number of the completed trials in a generation reaches the population
+// size, the parent population of the next generation is selected from them and the
+// previous parents by the non-dominated sorting and the crowding distance. The children
+// are generated by the crossover and the mutation of the parents chosen by the binary
+// tournament. Since the parent population is computed from the trials in the storage,
+// the sampler works with several workers sharing the storage.
+// See "A Fast and Elitist Multiobjective Genetic Algorithm: NSGA-II" by Deb et al.
+package nsga2
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"math/rand"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/c-bata/goptuna"
+	"github.com/c-bata/goptuna/internal/numerical"
+)
+
+var (
+	_ goptuna.RelativeSampler             = &Sampler{}
+	_ goptuna.RelativeSearchSpaceInferrer = &Sampler{}
+)
+
+const (
+	generationKey            = "goptuna:nsga2:generation"
+	parentsKey               = "goptuna:nsga2:parents"
+	populationCacheKeyPrefix = "goptuna:nsga2:population"
+)
+
+// Sampler returns the next search points by using NSGA-II.
+// The parameters of the first generation are sampled by the independent sampler of the study.
+type Sampler struct {
+	mu             sync.Mutex
+	rng            *rand.Rand
+	populationSize int
+	crossover      Crossover
+	crossoverProb  float64
+	mutation       Mutation
+	mutationProb   float64
+}
+
+// NewSampler returns the NSGA-II sampler.
+func NewSampler(opts ...SamplerOption) *Sampler {
+	sampler := &Sampler{
+		rng:            rand.New(rand.NewSource(0)),
+		populationSize: 50,
+		crossover:      UniformCrossover{SwappingProb: 0.5},
+		crossoverProb:  0.9,
+		mutation:       UniformMutation{},
+	}
+	for _, opt := range opts {
+		opt(sampler)
+	}
+	return sampler
+}
+
+// InferRelativeSearchSpace returns the intersection search space. Unlike the other
+// relative samplers, SampleRelative is called even before any trial is completed
+// to record the generation of the trial.
+func (s *Sampler) InferRelativeSearchSpace(
+	study *goptuna.Study,
+	trial goptuna.FrozenTrial,
+) (map[string]interface{}, error) {
+	searchSpace, err := goptuna.IntersectionSearchSpace(study)
+	if err != nil {
+		return nil, err
+	}
+	if searchSpace == nil {
+		searchSpace = make(map[string]interface{})
+	}
+	return searchSpace, nil
+}
+
+// SampleRelative samples multiple dimensional parameters in a given search space.
+func (s *Sampler) SampleRelative(
+	study *goptuna.Study,
+	trial goptuna.FrozenTrial,
+	searchSpace map[string]interface{},
+) (map[string]float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	parentGeneration, parentPopulation, err := s.collectParentPopulation(study)
+	if err != nil {
+		return nil, err
+	}
+	err = study.Storage.SetTrialSystemAttr(trial.ID, generationKey, strconv.Itoa(parentGeneration+1))
+	if err != nil {
+		return nil, err
+	}
+	if parentGeneration < 0 {
+		return nil, nil
+	}
+
+	directions := study.Directions()
+	p0 := s.selectParent(parentPopulation, directions)
+	p1 := p0
+	if len(parentPopulation) > 1 && s.rng.Float64() < s.crossoverProb {
+		others := make([]goptuna.FrozenTrial, 0, len(parentPopulation)-1)
+		for i := range parentPopulation {
+			if parentPopulation[i].ID != p0.ID {
+				others = append(others, parentPopulation[i])
+			}
+		}
+		p1 = s.selectParent(others, directions)
+	}
+	parents, err := json.Marshal([]int{p0.ID, p1.ID})
+	if err != nil {
+		return nil, err
+	}
+	if err = study.Storage.SetTrialSystemAttr(trial.ID, parentsKey, string(parents)); err != nil {
+		return nil, err
+	}
+	return s.generateChild(searchSpace, p0, p1)
+}
+
+// selectParent selects a parent by the binary tournament.
+func (s *Sampler) selectParent(population []goptuna.FrozenTrial, directions []goptuna.StudyDirection) goptuna.FrozenTrial {
+	if len(population) == 1 {
+		return population[0]
+	}
+	i := s.rng.Intn(len(population))
+	j := s.rng.Intn(len(population) - 1)
+	if j >= i {
+		j++
+	}
+	if goptuna.Dominates(objectiveValues(population[j]), objectiveValues(population[i]), directions) {
+		return population[j]
+	}
+	return population[i]
+}
+
+func (s *Sampler) generateChild(
+	searchSpace map[string]interface{},
+	p0, p1 goptuna.FrozenTrial,
+) (map[string]float64, error) {
+	names := make([]string, 0, len(searchSpace))
+	for name := range searchSpace {
+		if single, _ := goptuna.DistributionIsSingle(searchSpace[name]); single {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	mutationProb := s.mutationProb
+	if mutationProb <= 0 && len(names) > 0 {
+		mutationProb = 1 / float64(len(names))
+	}
+
+	params := make(map[string]float64, len(names))
+	for _, name := range names {
+		ir0, ok0 := p0.InternalParams[name]
+		ir1, ok1 := p1.InternalParams[name]
+		if !ok0 || !ok1 {
+			// The parameter is sampled by the independent sampler.
+			continue
+		}
+
+		if d, ok := searchSpace[name].(goptuna.CategoricalDistribution); ok {
+			child := ir0
+			if p0.ID != p1.ID && s.rng.Float64() < 0.5 {
+				child = ir1
+			}
+			if s.rng.Float64() < mutationProb {
+				child = float64(s.rng.Intn(len(d.Choices)))
+			}
+			params[name] = child
+			continue
+		}
+
+		d, ok := numerical.New(searchSpace[name])
+		if !ok {
+			return nil, goptuna.ErrUnsupportedSearchSpace
+		}
+		child := d.Normalize(ir0)
+		if p0.ID != p1.ID {
+			child = clip(s.crossover.Crossover(child, d.Normalize(ir1), s.rng))
+		}
+		if s.rng.Float64() < mutationProb {
+			child = clip(s.mutation.Mutate(child, s.rng))
+		}
+		params[name] = d.Denormalize(child)
+	}
+	return params, nil
+}
+
+type populationCache struct {
+	Generation int   `json:"generation"`
+	TrialIDs   []int `json:"trial_ids"`
+}
+
+// collectParentPopulation returns the latest generation whose population is
+// filled and its parent population selected from the elites. It returns -1 if
+// the population of the first generation is not filled yet.
+func (s *Sampler) collectParentPopulation(study *goptuna.Study) (int, []goptuna.FrozenTrial, error) {
+	trials, err := study.GetTrials()
+	if err != nil && err != goptuna.ErrTrialsPartiallyDeleted {
+		return 0, nil, err
+	}
+	studyAttrs, err := study.GetSystemAttrs()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	trialsByID := make(map[int]goptuna.FrozenTrial, len(trials))
+	generationToRunning := make(map[int][]goptuna.FrozenTrial)
+	generationToPopulation := make(map[int][]goptuna.FrozenTrial)
+	for i := range trials {
+		trialsByID[trials[i].ID] = trials[i]
+		generation, err := strconv.Atoi(trials[i].SystemAttrs[generationKey])
+		if err != nil {
+			continue
+		}
+		switch trials[i].State {
+		case goptuna.TrialStateRunning:
+			generationToRunning[generation] = append(generationToRunning[generation], trials[i])
+		case goptuna.TrialStateComplete:
+			generationToPopulation[generation] = append(generationToPopulation[generation], trials[i])
+		}
+	}
+
+	directions := study.Directions()
+	hasher := sha256.New()
+	parentGeneration := -1
+	var parentPopulation []goptuna.FrozenTrial
+	for {
+		generation := parentGeneration + 1
+		// The population might be larger than the population size with several workers.
+		population := generationToPopulation[generation]
+		if len(population) < s.populationSize {
+			break
+		}
+
+		// The running trials of the generation might be completed later, so the
+		// cache is identified by them.
+		cacheKey := populationCacheKey(hasher, generationToRunning[generation])
+		if cached, ok := loadPopulationCache(studyAttrs[cacheKey], trialsByID); ok && cached.Generation >= generation {
+			generation = cached.Generation
+			population = make([]goptuna.FrozenTrial, len(cached.TrialIDs))
+			for i, id := range cached.TrialIDs {
+				population[i] = trialsByID[id]
+			}
+		} else {
+			candidates := make([]goptuna.FrozenTrial, 0, len(population)+len(parentPopulation))
+			candidates = append(candidates, population...)
+			candidates = append(candidates, parentPopulation...)
+			population = selectElitePopulation(candidates, directions, s.populationSize)
+
+			// The population is cached only if it is fixed, i.e. there are no running
+			// trials, to reduce the number of the system attributes.
+			if len(generationToRunning[generation]) == 0 {
+				if err = savePopulationCache(study, cacheKey, generation, population); err != nil {
+					return 0, nil, err
+				}
+			}
+		}
+		parentGeneration = generation
+		parentPopulation = population
+	}
+	return parentGeneration, parentPopulation, nil
+}
+
+// populationCacheKey writes the numbers of the running trials of a generation to
+// the hasher, then returns the cache key of the parent population. The hasher is
+// shared across the generations on purpose because the parent population depends
+// on the populations of all previous generations, so the key of a generation covers
+// the running trials of all previous generations.
+func populationCacheKey(hasher hash.Hash, running []goptuna.FrozenTrial) string {
+	for i := range running {
+		hasher.Write([]byte(strconv.Itoa(running[i].Number) + ","))
+	}
+	// Separate the generations, e.g. [1],[] and [],[1] should be distinguished.
+	hasher.Write([]byte(";"))
+	return fmt.Sprintf("%s:%x", populationCacheKeyPrefix, hasher.Sum(nil))
+}
+
+func loadPopulationCache(value string, trialsByID map[int]goptuna.FrozenTrial) (populationCache, bool) {
+	var cache populationCache
+	if value == "" {
+		return cache, false
+	}
+	if err := json.Unmarshal([]byte(value), &cache); err != nil {
+		return cache, false
+	}
+	for _, id := range cache.TrialIDs {
+		if _, ok := trialsByID[id]; !ok {
+			return cache, false
+		}
+	}
+	return cache, true
+}
+
+func savePopulationCache(study *goptuna.Study, key string, generation int, population []goptuna.FrozenTrial) error {
+	cache := populationCache{
+		Generation: generation,
+		TrialIDs:   make([]int, len(population)),
+	}
+	for i := range population {
+		cache.TrialIDs[i] = population[i].ID
+	}
+	value, err := json.Marshal(cache)
+	if err != nil {
+		return err
+	}
+	return study.SetSystemAttr(key, string(value))
+}
+
+func clip(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	if x > 1 {
+		return 1
+	}
+	return x
+}
@@ -0,0 +1,54 @@
+package nsga2
+
+import (
+	"math/rand"
+)
+
+// SamplerOption is a type of the function to customizing NSGA-II sampler.
+type SamplerOption func(sampler *Sampler)
+
+// SamplerOptionSeed sets seed number.
+func SamplerOptionSeed(seed int64) SamplerOption {
+	return func(sampler *Sampler) {
+		sampler.rng = rand.New(rand.NewSource(seed))
+	}
+}
+
+// SamplerOptionPopulationSize sets the number of individuals in each generation (default 50).
+func SamplerOptionPopulationSize(populationSize int) SamplerOption {
+	return func(sampler *Sampler) {
+		sampler.populationSize = populationSize
+	}
+}
+
+// SamplerOptionCrossover sets the crossover operator for the numerical parameters
+// (default UniformCrossover with the swapping probability 0.5).
+func SamplerOptionCrossover(crossover Crossover) SamplerOption {
+	return func(sampler *Sampler) {
+		sampler.crossover = crossover
+	}
+}
+
+// SamplerOptionCrossoverProb sets the probability that a child is generated from
+// two parents (default 0.9). Otherwise, the child is a copy of a single parent.
+func SamplerOptionCrossoverProb(crossoverProb float64) SamplerOption {
+	return func(sampler *Sampler) {
+		sampler.crossoverProb = crossoverProb
+	}
+}
+
+// SamplerOptionMutation sets the mutation operator for the numerical parameters
+// (default UniformMutation).
+func SamplerOptionMutation(mutation Mutation) SamplerOption {
+	return func(sampler *Sampler) {
+		sampler.mutation = mutation
+	}
+}
+
+// SamplerOptionMutationProb sets the probability of the mutation of each parameter.
+// The default is 1 divided by the number of the parameters.
+func SamplerOptionMutationProb(mutationProb float64) SamplerOption {
+	return func(sampler *Sampler) {
+		sampler.mutationProb = mutationProb
+	}
+}
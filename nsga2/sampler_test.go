@@ -0,0 +1,162 @@
+package nsga2_test
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/c-bata/goptuna"
+	"github.com/c-bata/goptuna/nsga2"
+)
+
+// Binh and Korn function with an integer and a categorical parameter.
+func objective(trial goptuna.Trial) ([]float64, error) {
+	x, _ := trial.SuggestFloat("x", 0, 5)
+	y, _ := trial.SuggestFloat("y", 0, 3)
+	n, _ := trial.SuggestInt("n", 0, 10)
+	kind, _ := trial.SuggestCategorical("kind", []string{"a", "b"})
+
+	v1 := 4*math.Pow(x, 2) + 4*math.Pow(y, 2) + float64(n)
+	v2 := math.Pow(x-5, 2) + math.Pow(y-5, 2) + float64(n)
+	if kind == "b" {
+		v1++
+	}
+	return []float64{v1, v2}, nil
+}
+
+func TestSampler_SampleRelative(t *testing.T) {
+	tests := []struct {
+		name      string
+		crossover nsga2.Crossover
+		mutation  nsga2.Mutation
+		nJobs     int
+	}{
+		{
+			name:      "uniform crossover",
+			crossover: nsga2.UniformCrossover{SwappingProb: 0.5},
+			mutation:  nsga2.UniformMutation{},
+			nJobs:     1,
+		},
+		{
+			name:      "BLX-alpha crossover",
+			crossover: nsga2.BLXAlphaCrossover{Alpha: 0.5},
+			mutation:  nsga2.PolynomialMutation{Eta: 20},
+			nJobs:     1,
+		},
+		{
+			name:      "SBX crossover with several workers",
+			crossover: nsga2.SBXCrossover{Eta: 15},
+			mutation:  nsga2.PolynomialMutation{Eta: 20},
+			nJobs:     4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			populationSize := 8
+			study, err := goptuna.CreateStudy("nsga2",
+				goptuna.StudyOptionDirections([]goptuna.StudyDirection{
+					goptuna.StudyDirectionMinimize,
+					goptuna.StudyDirectionMinimize,
+				}),
+				goptuna.StudyOptionRelativeSampler(nsga2.NewSampler(
+					nsga2.SamplerOptionSeed(0),
+					nsga2.SamplerOptionPopulationSize(populationSize),
+					nsga2.SamplerOptionCrossover(tt.crossover),
+					nsga2.SamplerOptionMutation(tt.mutation))),
+				goptuna.StudyOptionNJobs(tt.nJobs),
+				goptuna.StudyOptionLogger(nil),
+			)
+			if err != nil {
+				t.Errorf("should be nil, but got %s", err)
+				return
+			}
+			if err = study.OptimizeMulti(objective, 5*populationSize); err != nil {
+				t.Errorf("should be nil, but got %s", err)
+				return
+			}
+
+			trials, err := study.GetTrials()
+			if err != nil {
+				t.Errorf("should be nil, but got %s", err)
+				return
+			}
+			generations := make(map[int]int, len(trials))
+			for _, trial := range trials {
+				generation, err := strconv.Atoi(trial.SystemAttrs["goptuna:nsga2:generation"])
+				if err != nil {
+					t.Errorf("trial %d should have the generation, but got %s", trial.Number, err)
+					return
+				}
+				generations[trial.ID] = generation
+			}
+
+			maxGeneration := 0
+			for _, trial := range trials {
+				for name, ir := range trial.InternalParams {
+					d := trial.Distributions[name].(goptuna.Distribution)
+					if !d.Contains(ir) {
+						t.Errorf("trial %d: %s=%f is not contained in %#v", trial.Number, name, ir, d)
+					}
+				}
+
+				generation := generations[trial.ID]
+				if generation > maxGeneration {
+					maxGeneration = generation
+				}
+				if generation == 0 {
+					if _, ok := trial.SystemAttrs["goptuna:nsga2:parents"]; ok {
+						t.Errorf("trial %d of the first generation should not have parents", trial.Number)
+					}
+					continue
+				}
+				var parents []int
+				if err = json.Unmarshal([]byte(trial.SystemAttrs["goptuna:nsga2:parents"]), &parents); err != nil {
+					t.Errorf("trial %d should have parents, but got %s", trial.Number, err)
+					return
+				}
+				for _, id := range parents {
+					if generations[id] >= generation {
+						t.Errorf("trial %d of generation %d has a parent of generation %d",
+							trial.Number, generation, generations[id])
+					}
+				}
+			}
+			if maxGeneration < 2 {
+				t.Errorf("the generation should be updated, but got %d", maxGeneration)
+			}
+		})
+	}
+}
+
+func TestSampler_SingleObjective(t *testing.T) {
+	study, err := goptuna.CreateStudy("nsga2",
+		goptuna.StudyOptionRelativeSampler(nsga2.NewSampler(
+			nsga2.SamplerOptionSeed(0),
+			nsga2.SamplerOptionPopulationSize(10),
+			nsga2.SamplerOptionCrossover(nsga2.SBXCrossover{Eta: 15}),
+			nsga2.SamplerOptionMutation(nsga2.PolynomialMutation{Eta: 20}))),
+		goptuna.StudyOptionLogger(nil),
+	)
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	err = study.Optimize(func(trial goptuna.Trial) (float64, error) {
+		x1, _ := trial.SuggestFloat("x1", -10, 10)
+		x2, _ := trial.SuggestFloat("x2", -10, 10)
+		return math.Pow(x1-2, 2) + math.Pow(x2+5, 2), nil
+	}, 200)
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	value, err := study.GetBestValue()
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	if value > 1 {
+		t.Errorf("best value %f should be smaller than 1", value)
+	}
+}
@@ -0,0 +1,133 @@
+package nsga2
+
+import (
+	"math"
+	"sort"
+
+	"github.com/c-bata/goptuna"
+)
+
+func objectiveValues(trial goptuna.FrozenTrial) []float64 {
+	if len(trial.Values) > 0 {
+		return trial.Values
+	}
+	return []float64{trial.Value}
+}
+
+// fastNonDominatedSort returns the indices of the population in each front.
+// The first front consists of the non-dominated individuals.
+func fastNonDominatedSort(values [][]float64, directions []goptuna.StudyDirection) [][]int {
+	n := len(values)
+	dominatedBy := make([][]int, n)
+	dominationCounts := make([]int, n)
+	var front []int
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i == j {
+				continue
+			}
+			if goptuna.Dominates(values[i], values[j], directions) {
+				dominatedBy[i] = append(dominatedBy[i], j)
+			} else if goptuna.Dominates(values[j], values[i], directions) {
+				dominationCounts[i]++
+			}
+		}
+		if dominationCounts[i] == 0 {
+			front = append(front, i)
+		}
+	}
+
+	var fronts [][]int
+	for len(front) > 0 {
+		fronts = append(fronts, front)
+		var next []int
+		for _, i := range front {
+			for _, j := range dominatedBy[i] {
+				dominationCounts[j]--
+				if dominationCounts[j] == 0 {
+					next = append(next, j)
+				}
+			}
+		}
+		sort.Ints(next)
+		front = next
+	}
+	return fronts
+}
+
+// crowdingDistance returns the crowding distances of the individuals in a front.
+// The individuals at the boundaries of each objective have the infinite distances.
+func crowdingDistance(values [][]float64) []float64 {
+	n := len(values)
+	distances := make([]float64, n)
+	if n == 0 {
+		return distances
+	}
+	indices := make([]int, n)
+	for m := range values[0] {
+		for i := range indices {
+			indices[i] = i
+		}
+		sort.SliceStable(indices, func(i, j int) bool {
+			return values[indices[i]][m] < values[indices[j]][m]
+		})
+		low, high := values[indices[0]][m], values[indices[n-1]][m]
+		distances[indices[0]] = math.Inf(1)
+		distances[indices[n-1]] = math.Inf(1)
+		if high == low {
+			continue
+		}
+		for k := 1; k < n-1; k++ {
+			distances[indices[k]] += (values[indices[k+1]][m] - values[indices[k-1]][m]) / (high - low)
+		}
+	}
+	return distances
+}
+
+// selectElitePopulation selects the individuals of the next parent population
+// by the non-dominated sorting and the crowding distance. The result doesn't
+// depend on the order of the given population, so that all workers sharing the
+// storage agree on the same parent population.
+func selectElitePopulation(
+	population []goptuna.FrozenTrial,
+	directions []goptuna.StudyDirection,
+	size int,
+) []goptuna.FrozenTrial {
+	sorted := make([]goptuna.FrozenTrial, len(population))
+	copy(sorted, population)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Number < sorted[j].Number
+	})
+	values := make([][]float64, len(sorted))
+	for i := range sorted {
+		values[i] = objectiveValues(sorted[i])
+	}
+
+	elite := make([]goptuna.FrozenTrial, 0, size)
+	for _, front := range fastNonDominatedSort(values, directions) {
+		if len(elite)+len(front) <= size {
+			for _, i := range front {
+				elite = append(elite, sorted[i])
+			}
+			continue
+		}
+
+		frontValues := make([][]float64, len(front))
+		for k, i := range front {
+			frontValues[k] = values[i]
+		}
+		distances := crowdingDistance(frontValues)
+		order := make([]int, len(front))
+		for k := range order {
+			order[k] = k
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			return distances[order[a]] > distances[order[b]]
+		})
+		for _, k := range order[:size-len(elite)] {
+			elite = append(elite, sorted[front[k]])
+		}
+		break
+	}
+	return elite
+}
@@ -0,0 +1,68 @@
+package nsga2
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/c-bata/goptuna"
+)
+
+func TestFastNonDominatedSort(t *testing.T) {
+	directions := []goptuna.StudyDirection{
+		goptuna.StudyDirectionMinimize,
+		goptuna.StudyDirectionMaximize,
+	}
+	values := [][]float64{
+		{1, 1}, // 0: front 2 (dominated by 2)
+		{3, 5}, // 1: front 1
+		{1, 3}, // 2: front 1
+		{2, 2}, // 3: front 2 (dominated by 2)
+		{4, 1}, // 4: front 3 (dominated by 0, 3)
+		{1, 3}, // 5: front 1 (same as 2)
+	}
+	got := fastNonDominatedSort(values, directions)
+	want := [][]int{{1, 2, 5}, {0, 3}, {4}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("fastNonDominatedSort() = %v, want %v", got, want)
+	}
+}
+
+func TestCrowdingDistance(t *testing.T) {
+	values := [][]float64{
+		{0, 4},
+		{4, 0},
+		{1, 2},
+		{3, 1},
+	}
+	got := crowdingDistance(values)
+	want := []float64{math.Inf(1), math.Inf(1), 3.0/4 + 3.0/4, 3.0/4 + 2.0/4}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("crowdingDistance() = %v, want %v", got, want)
+	}
+}
+
+func TestSelectElitePopulation(t *testing.T) {
+	directions := []goptuna.StudyDirection{
+		goptuna.StudyDirectionMinimize,
+		goptuna.StudyDirectionMinimize,
+	}
+	population := []goptuna.FrozenTrial{
+		{ID: 4, Number: 4, Values: []float64{3, 1}},
+		{ID: 0, Number: 0, Values: []float64{0, 4}},
+		{ID: 1, Number: 1, Values: []float64{4, 0}},
+		{ID: 2, Number: 2, Values: []float64{1, 2}},
+		{ID: 3, Number: 3, Values: []float64{5, 5}},
+	}
+	elite := selectElitePopulation(population, directions, 3)
+	var got []int
+	for _, trial := range elite {
+		got = append(got, trial.Number)
+	}
+	// The boundaries of the first front are selected first, and trial 2 has
+	// the larger crowding distance than trial 4.
+	want := []int{0, 1, 2}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("selectElitePopulation() = %v, want %v", got, want)
+	}
+}
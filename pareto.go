@@ -0,0 +1,45 @@
+package goptuna
+
+// Dominates returns true if the objective values 'a' dominate 'b', i.e., 'a' is
+// not worse than 'b' in all objectives and strictly better in at least one objective.
+func Dominates(a, b []float64, directions []StudyDirection) bool {
+	if len(a) != len(b) || len(a) != len(directions) {
+		return false
+	}
+
+	betterInAnyObjective := false
+	for i := range directions {
+		x, y := a[i], b[i]
+		if directions[i] == StudyDirectionMaximize {
+			x, y = -x, -y
+		}
+		if x > y {
+			return false
+		}
+		if x < y {
+			betterInAnyObjective = true
+		}
+	}
+	return betterInAnyObjective
+}
+
+// paretoFront returns the trials which are not dominated by any other trials.
+func paretoFront(trials []FrozenTrial, directions []StudyDirection) []FrozenTrial {
+	front := make([]FrozenTrial, 0, len(trials))
+	for i := range trials {
+		dominated := false
+		for j := range trials {
+			if i == j {
+				continue
+			}
+			if Dominates(trials[j].Values, trials[i].Values, directions) {
+				dominated = true
+				break
+			}
+		}
+		if !dominated {
+			front = append(front, trials[i])
+		}
+	}
+	return front
+}
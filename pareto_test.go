@@ -0,0 +1,33 @@
+package goptuna_test
+
+import (
+	"testing"
+
+	"github.com/c-bata/goptuna"
+)
+
+func TestDominates(t *testing.T) {
+	minMax := []goptuna.StudyDirection{
+		goptuna.StudyDirectionMinimize,
+		goptuna.StudyDirectionMaximize,
+	}
+	tests := []struct {
+		name string
+		a    []float64
+		b    []float64
+		want bool
+	}{
+		{name: "better in all objectives", a: []float64{1, 2}, b: []float64{2, 1}, want: true},
+		{name: "better in one objective", a: []float64{1, 1}, b: []float64{2, 1}, want: true},
+		{name: "worse in one objective", a: []float64{1, 1}, b: []float64{2, 2}, want: false},
+		{name: "same values", a: []float64{1, 1}, b: []float64{1, 1}, want: false},
+		{name: "different length", a: []float64{1}, b: []float64{2, 1}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := goptuna.Dominates(tt.a, tt.b, minMax); got != tt.want {
+				t.Errorf("Dominates() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
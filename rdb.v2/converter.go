@@ -53,9 +53,21 @@ func toFrozenTrial(trial trialModel) (goptuna.FrozenTrial, error) {
 		datetimeComplete = *trial.DatetimeComplete
 	}
 
-	intermediateValue := make(map[int]float64, len(trial.TrialValues))
-	for i := range trial.TrialValues {
-		intermediateValue[trial.TrialValues[i].Step] = trial.TrialValues[i].Value
+	intermediateValue := make(map[int]float64, len(trial.TrialIntermediateValues))
+	for i := range trial.TrialIntermediateValues {
+		intermediateValue[trial.TrialIntermediateValues[i].Step] = trial.TrialIntermediateValues[i].IntermediateValue
+	}
+
+	var values []float64
+	if len(trial.TrialValues) > 0 {
+		values = make([]float64, len(trial.TrialValues))
+		for i := range trial.TrialValues {
+			objective := trial.TrialValues[i].Objective
+			if objective < 0 || objective >= len(values) {
+				return goptuna.FrozenTrial{}, errors.New("invalid objective index")
+			}
+			values[objective] = trial.TrialValues[i].Value
+		}
 	}
 
 	return goptuna.FrozenTrial{
@@ -64,6 +76,7 @@ func toFrozenTrial(trial trialModel) (goptuna.FrozenTrial, error) {
 		Number:             trial.Number,
 		State:              state,
 		Value:              trial.Value,
+		Values:             values,
 		IntermediateValues: intermediateValue,
 		DatetimeStart:      datetimeStart,
 		DatetimeComplete:   datetimeComplete,
@@ -85,10 +98,12 @@ func toStudySummary(study studyModel, bestTrial goptuna.FrozenTrial, start time.
 	for i := range study.SystemAttributes {
 		systemAttrs[study.SystemAttributes[i].Key] = study.SystemAttributes[i].Value
 	}
+	directions := toGoptunaStudyDirections(study)
 	return goptuna.StudySummary{
 		ID:            study.ID,
 		Name:          study.Name,
-		Direction:     toGoptunaStudyDirection(study.Direction),
+		Direction:     directions[0],
+		Directions:    directions,
 		BestTrial:     bestTrial,
 		UserAttrs:     userAttrs,
 		SystemAttrs:   systemAttrs,
@@ -140,3 +155,27 @@ func toGoptunaStudyDirection(direction string) goptuna.StudyDirection {
 		return goptuna.StudyDirectionMinimize
 	}
 }
+
+// toGoptunaStudyDirections returns the directions of the study. The directions
+// fall back to 'studies.direction' column if 'study_directions' table has no rows.
+func toGoptunaStudyDirections(study studyModel) []goptuna.StudyDirection {
+	if len(study.Directions) == 0 {
+		return []goptuna.StudyDirection{toGoptunaStudyDirection(study.Direction)}
+	}
+	directions := make([]goptuna.StudyDirection, len(study.Directions))
+	for i := range study.Directions {
+		objective := study.Directions[i].Objective
+		if objective < 0 || objective >= len(directions) {
+			return []goptuna.StudyDirection{toGoptunaStudyDirection(study.Direction)}
+		}
+		directions[objective] = toGoptunaStudyDirection(study.Directions[i].Direction)
+	}
+	return directions
+}
+
+func toDirectionInternalRepresentation(direction goptuna.StudyDirection) string {
+	if direction == goptuna.StudyDirectionMaximize {
+		return directionMaximize
+	}
+	return directionMinimize
+}
@@ -27,6 +27,7 @@ type studyModel struct {
 	Direction string `gorm:"column:direction;not null"`
 
 	// Associations
+	Directions       []studyDirectionModel       `gorm:"Constraint:OnDelete:CASCADE;foreignkey:DirectionReferStudy;association_foreignkey:ID"`
 	UserAttributes   []studyUserAttributeModel   `gorm:"Constraint:OnDelete:CASCADE;foreignkey:UserAttributeReferStudy;association_foreignkey:ID"`
 	SystemAttributes []studySystemAttributeModel `gorm:"Constraint:OnDelete:CASCADE;foreignkey:SystemAttributeReferStudy;association_foreignkey:ID"`
 	Trials           []trialModel                `gorm:"Constraint:OnDelete:CASCADE;foreignkey:TrialReferStudy;association_foreignkey:ID"`
@@ -36,6 +37,17 @@ func (m studyModel) TableName() string {
 	return "studies"
 }
 
+type studyDirectionModel struct {
+	ID                  int    `gorm:"column:study_direction_id;primaryKey"`
+	Direction           string `gorm:"column:direction;not null"`
+	DirectionReferStudy int    `gorm:"column:study_id;uniqueIndex:idx_study_direction_objective"`
+	Objective           int    `gorm:"column:objective;uniqueIndex:idx_study_direction_objective"`
+}
+
+func (m studyDirectionModel) TableName() string {
+	return "study_directions"
+}
+
 type studyUserAttributeModel struct {
 	ID                      int    `gorm:"column:study_user_attribute_id;primaryKey"`
 	UserAttributeReferStudy int    `gorm:"column:study_id;uniqueIndex:idx_study_user_attr_key"`
@@ -68,10 +80,12 @@ type trialModel struct {
 	DatetimeComplete *time.Time `gorm:"column:datetime_complete"`
 
 	// Associations
-	UserAttributes   []trialUserAttributeModel   `gorm:"Constraint:OnDelete:CASCADE;foreignkey:UserAttributeReferTrial;association_foreignkey:ID"`
-	SystemAttributes []trialSystemAttributeModel `gorm:"Constraint:OnDelete:CASCADE;foreignkey:SystemAttributeReferTrial;association_foreignkey:ID"`
-	TrialParams      []trialParamModel           `gorm:"Constraint:OnDelete:CASCADE;foreignkey:TrialParamReferTrial;association_foreignkey:ID"`
-	TrialValues      []trialValueModel           `gorm:"Constraint:OnDelete:CASCADE;foreignkey:TrialValueReferTrial;association_foreignkey:ID"`
+	UserAttributes          []trialUserAttributeModel     `gorm:"Constraint:OnDelete:CASCADE;foreignkey:UserAttributeReferTrial;association_foreignkey:ID"`
+	SystemAttributes        []trialSystemAttributeModel   `gorm:"Constraint:OnDelete:CASCADE;foreignkey:SystemAttributeReferTrial;association_foreignkey:ID"`
+	TrialParams             []trialParamModel             `gorm:"Constraint:OnDelete:CASCADE;foreignkey:TrialParamReferTrial;association_foreignkey:ID"`
+	TrialValues             []trialValueModel             `gorm:"Constraint:OnDelete:CASCADE;foreignkey:TrialValueReferTrial;association_foreignkey:ID"`
+	TrialIntermediateValues []trialIntermediateValueModel `gorm:"Constraint:OnDelete:CASCADE;foreignkey:IntermediateValueReferTrial;association_foreignkey:ID"`
+	TrialHeartbeats         []trialHeartbeatModel         `gorm:"Constraint:OnDelete:CASCADE;foreignkey:HeartbeatReferTrial;association_foreignkey:ID"`
 }
 
 func (m trialModel) TableName() string {
@@ -113,25 +127,100 @@ func (m trialParamModel) TableName() string {
 }
 
 type trialValueModel struct {
+	ID                   int     `gorm:"column:trial_value_id;primaryKey"`
+	TrialValueReferTrial int     `gorm:"column:trial_id;uniqueIndex:idx_trial_value_objective"`
+	Objective            int     `gorm:"column:objective;uniqueIndex:idx_trial_value_objective"`
+	Value                float64 `gorm:"column:value"`
+}
+
+func (m trialValueModel) TableName() string {
+	return "trial_values"
+}
+
+type trialIntermediateValueModel struct {
+	ID                          int     `gorm:"column:trial_intermediate_value_id;primaryKey"`
+	IntermediateValueReferTrial int     `gorm:"column:trial_id;uniqueIndex:idx_trial_intermediate_value_step"`
+	Step                        int     `gorm:"column:step;uniqueIndex:idx_trial_intermediate_value_step"`
+	IntermediateValue           float64 `gorm:"column:intermediate_value"`
+}
+
+func (m trialIntermediateValueModel) TableName() string {
+	return "trial_intermediate_values"
+}
+
+type trialHeartbeatModel struct {
+	ID                  int       `gorm:"column:trial_heartbeat_id;primaryKey"`
+	HeartbeatReferTrial int       `gorm:"column:trial_id;unique;not null"`
+	Heartbeat           time.Time `gorm:"column:heartbeat;not null"`
+}
+
+func (m trialHeartbeatModel) TableName() string {
+	return "trial_heartbeats"
+}
+
+// legacyTrialValueModel is a schema of 'trial_values' table created by older
+// versions of Goptuna, which stores intermediate values instead of objective values.
+type legacyTrialValueModel struct {
 	ID                   int     `gorm:"column:trial_value_id;primaryKey"`
 	TrialValueReferTrial int     `gorm:"column:trial_id;uniqueIndex:idx_trial_value_step"`
 	Step                 int     `gorm:"column:step;uniqueIndex:idx_trial_value_step"`
 	Value                float64 `gorm:"column:value"`
 }
 
-func (m trialValueModel) TableName() string {
+func (m legacyTrialValueModel) TableName() string {
 	return "trial_values"
 }
 
+// migrateLegacyTrialValues moves intermediate values in 'trial_values' table
+// to 'trial_intermediate_values' table, then drops the index and the column
+// of the steps. It returns true if the legacy schema is migrated.
+func migrateLegacyTrialValues(db *gorm.DB) (bool, error) {
+	migrator := db.Migrator()
+	if !migrator.HasTable(&legacyTrialValueModel{}) ||
+		!migrator.HasColumn(&legacyTrialValueModel{}, "step") {
+		return false, nil
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec("INSERT INTO trial_intermediate_values (trial_id, step, intermediate_value)" +
+			" SELECT trial_id, step, value FROM trial_values").Error
+		if err != nil {
+			return err
+		}
+		err = tx.Exec("DELETE FROM trial_values").Error
+		if err != nil {
+			return err
+		}
+		if tx.Migrator().HasIndex(&legacyTrialValueModel{}, "idx_trial_value_step") {
+			err = tx.Migrator().DropIndex(&legacyTrialValueModel{}, "idx_trial_value_step")
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Migrator().DropColumn(&legacyTrialValueModel{}, "step")
+	})
+	return err == nil, err
+}
+
 // RunAutoMigrate runs Auto-Migration. This will ONLY create tables,
 // missing columns and missing indexes, and WON’T change existing
 // column’s type or delete unused columns to protect your data.
+//
+// Objective values are stored in 'trial_values' table and intermediate values
+// are stored in 'trial_intermediate_values' table like Optuna v2.4.0 or later.
+// Intermediate values which are stored in 'trial_values' table by older versions
+// of Goptuna are moved to 'trial_intermediate_values' table, and the values of
+// the completed trials are stored as the first objective values.
+// Heartbeats of the running trials are stored in 'trial_heartbeats' table.
 func RunAutoMigrate(db *gorm.DB) error {
 	var err error
 	err = db.AutoMigrate(&studyModel{})
 	if err != nil {
 		return err
 	}
+	err = db.AutoMigrate(&studyDirectionModel{})
+	if err != nil {
+		return err
+	}
 	err = db.AutoMigrate(&studyUserAttributeModel{})
 	if err != nil {
 		return err
@@ -156,9 +245,32 @@ func RunAutoMigrate(db *gorm.DB) error {
 	if err != nil {
 		return err
 	}
+	err = db.AutoMigrate(&trialIntermediateValueModel{})
+	if err != nil {
+		return err
+	}
+	migrated, err := migrateLegacyTrialValues(db)
+	if err != nil {
+		return err
+	}
 	err = db.AutoMigrate(&trialValueModel{})
 	if err != nil {
 		return err
 	}
+	if migrated {
+		// Pruned trials may have the value too. Running and waiting trials
+		// always have the zero value in the older versions, so skip them.
+		err = db.Exec("INSERT INTO trial_values (trial_id, objective, value)"+
+			" SELECT trial_id, 0, value FROM trials"+
+			" WHERE value IS NOT NULL AND state NOT IN (?, ?)",
+			trialStateRunning, trialStateWaiting).Error
+		if err != nil {
+			return err
+		}
+	}
+	err = db.AutoMigrate(&trialHeartbeatModel{})
+	if err != nil {
+		return err
+	}
 	return nil
 }
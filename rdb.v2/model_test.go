@@ -4,12 +4,15 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"reflect"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/c-bata/goptuna"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var (
@@ -108,6 +111,15 @@ func TestStudyCascadeOnDelete(t *testing.T) {
 		t.Errorf("trials table should be empty, but got count=%d", count)
 	}
 
+	// Count study_directions table
+	if db.Model(&studyDirectionModel{}).Count(&count).Error != nil {
+		t.Errorf("failed to count trial model: %s", err)
+		return
+	}
+	if count != 0 {
+		t.Errorf("trials table should be empty, but got count=%d", count)
+	}
+
 	// Count study_system_attributes table
 	if db.Model(&studySystemAttributeModel{}).Count(&count).Error != nil {
 		t.Errorf("failed to count trial model: %s", err)
@@ -135,6 +147,15 @@ func TestStudyCascadeOnDelete(t *testing.T) {
 		t.Errorf("trials table should be empty, but got count=%d", count)
 	}
 
+	// Count trial_intermediate_values table
+	if db.Model(&trialIntermediateValueModel{}).Count(&count).Error != nil {
+		t.Errorf("failed to count trial model: %s", err)
+		return
+	}
+	if count != 0 {
+		t.Errorf("trials table should be empty, but got count=%d", count)
+	}
+
 	// Count trial_params table
 	if db.Model(&trialParamModel{}).Count(&count).Error != nil {
 		t.Errorf("failed to count trial model: %s", err)
@@ -162,3 +183,120 @@ func TestStudyCascadeOnDelete(t *testing.T) {
 		t.Errorf("trials table should be empty, but got count=%d", count)
 	}
 }
+
+func TestRunAutoMigrate_LegacyTrialValues(t *testing.T) {
+	setupCounterMu.Lock()
+	setupCounter++
+	sqlitePath := fmt.Sprintf("goptuna-test-%d.db", setupCounter)
+	setupCounterMu.Unlock()
+	defer os.Remove(sqlitePath)
+
+	db, err := gorm.Open(sqlite.Open(sqlitePath), &gorm.Config{})
+	if err != nil {
+		t.Errorf("failed to open database: %s", err)
+		return
+	}
+	// Create the schema of the older versions.
+	if err = db.AutoMigrate(&studyModel{}, &trialModel{}, &legacyTrialValueModel{}); err != nil {
+		t.Errorf("failed to create legacy tables: %s", err)
+		return
+	}
+	now := time.Now()
+	study := studyModel{Name: "legacy", Direction: directionMinimize}
+	if err = db.Create(&study).Error; err != nil {
+		t.Errorf("failed to create study: %s", err)
+		return
+	}
+	trial := trialModel{
+		TrialReferStudy:  study.ID,
+		State:            trialStateComplete,
+		Value:            0.1,
+		DatetimeStart:    &now,
+		DatetimeComplete: &now,
+	}
+	if err = db.Omit(clause.Associations).Create(&trial).Error; err != nil {
+		t.Errorf("failed to create trial: %s", err)
+		return
+	}
+	pruned := trialModel{
+		TrialReferStudy:  study.ID,
+		State:            trialStatePruned,
+		Value:            0.4,
+		DatetimeStart:    &now,
+		DatetimeComplete: &now,
+	}
+	if err = db.Omit(clause.Associations).Create(&pruned).Error; err != nil {
+		t.Errorf("failed to create trial: %s", err)
+		return
+	}
+	running := trialModel{
+		TrialReferStudy: study.ID,
+		State:           trialStateRunning,
+		DatetimeStart:   &now,
+	}
+	if err = db.Omit(clause.Associations).Create(&running).Error; err != nil {
+		t.Errorf("failed to create trial: %s", err)
+		return
+	}
+	for step, value := range []float64{0.3, 0.2} {
+		err = db.Create(&legacyTrialValueModel{
+			TrialValueReferTrial: trial.ID,
+			Step:                 step,
+			Value:                value,
+		}).Error
+		if err != nil {
+			t.Errorf("failed to create legacy trial value: %s", err)
+			return
+		}
+	}
+
+	if err = RunAutoMigrate(db); err != nil {
+		t.Errorf("failed to migrate: %s", err)
+		return
+	}
+	if db.Migrator().HasIndex(&legacyTrialValueModel{}, "idx_trial_value_step") {
+		t.Errorf("legacy index should be dropped")
+	}
+
+	got, err := NewStorage(db).GetTrial(trial.ID)
+	if err != nil {
+		t.Errorf("failed to get trial: %s", err)
+		return
+	}
+	if !reflect.DeepEqual(got.IntermediateValues, map[int]float64{0: 0.3, 1: 0.2}) {
+		t.Errorf("intermediate values should be migrated, but got %v", got.IntermediateValues)
+	}
+	if !reflect.DeepEqual(got.Values, []float64{0.1}) {
+		t.Errorf("values should be []float64{0.1}, but got %v", got.Values)
+	}
+	got, err = NewStorage(db).GetTrial(pruned.ID)
+	if err != nil {
+		t.Errorf("failed to get trial: %s", err)
+		return
+	}
+	if !reflect.DeepEqual(got.Values, []float64{0.4}) {
+		t.Errorf("values of the pruned trial should be []float64{0.4}, but got %v", got.Values)
+	}
+	got, err = NewStorage(db).GetTrial(running.ID)
+	if err != nil {
+		t.Errorf("failed to get trial: %s", err)
+		return
+	}
+	if len(got.Values) != 0 {
+		t.Errorf("running trial should not have values, but got %v", got.Values)
+	}
+
+	// Running again should be no-op.
+	if err = RunAutoMigrate(db); err != nil {
+		t.Errorf("failed to migrate: %s", err)
+		return
+	}
+	var count int64
+	if err = db.Model(&trialValueModel{}).Count(&count).Error; err != nil {
+		t.Errorf("failed to count trial values: %s", err)
+		return
+	}
+	if count != 2 {
+		t.Errorf("trial_values table should have 2 rows, but got count=%d", count)
+	}
+}
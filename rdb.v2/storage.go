@@ -14,6 +14,8 @@ import (
 )
 
 var _ goptuna.Storage = &Storage{}
+var _ goptuna.HeartbeatStorage = &Storage{}
+var _ goptuna.StudySystemAttrCreator = &Storage{}
 
 // NewStorage returns new RDB storage.
 func NewStorage(db *gorm.DB) *Storage {
@@ -44,6 +46,10 @@ func (s *Storage) CreateNewStudy(name string) (int, error) {
 	study := &studyModel{
 		Name:      name,
 		Direction: directionMinimize,
+		Directions: []studyDirectionModel{{
+			Direction: directionMinimize,
+			Objective: 0,
+		}},
 	}
 	err := s.db.Create(study).Error
 	return study.ID, err
@@ -58,15 +64,42 @@ func (s *Storage) DeleteStudy(studyID int) error {
 
 // SetStudyDirection sets study direction of the objective.
 func (s *Storage) SetStudyDirection(studyID int, direction goptuna.StudyDirection) error {
-	d := directionMinimize
-	if direction == goptuna.StudyDirectionMaximize {
-		d = directionMaximize
+	return s.SetStudyDirections(studyID, []goptuna.StudyDirection{direction})
+}
+
+// SetStudyDirections sets study directions of the multiple objectives.
+func (s *Storage) SetStudyDirections(studyID int, directions []goptuna.StudyDirection) error {
+	if len(directions) == 0 {
+		return errors.New("directions must contain one or more elements")
 	}
 
-	err := s.db.Model(&studyModel{}).
-		Where("study_id = ?", studyID).
-		Update("direction", d).Error
-	return err
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		// 'studies.direction' column holds the direction of the first objective
+		// to keep the compatibility with older versions of Goptuna.
+		err := tx.Model(&studyModel{}).
+			Where("study_id = ?", studyID).
+			Update("direction", toDirectionInternalRepresentation(directions[0])).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Where("study_id = ?", studyID).
+			Delete(&studyDirectionModel{}).Error
+		if err != nil {
+			return err
+		}
+		for i := range directions {
+			err = tx.Create(&studyDirectionModel{
+				Direction:           toDirectionInternalRepresentation(directions[i]),
+				DirectionReferStudy: studyID,
+				Objective:           i,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
 // SetStudyUserAttr to store the value for the user.
@@ -95,6 +128,20 @@ func (s *Storage) SetStudySystemAttr(studyID int, key string, value string) erro
 	}).FirstOrCreate(&result).Error
 }
 
+// CreateStudySystemAttr stores the value for the system only if the key doesn't exist.
+// The unique index of the study system attributes makes this atomic.
+func (s *Storage) CreateStudySystemAttr(studyID int, key string, value string) (bool, error) {
+	result := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&studySystemAttributeModel{
+		SystemAttributeReferStudy: studyID,
+		Key:                       key,
+		Value:                     value,
+	})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
 // GetStudyIDFromName return the study id from study name.
 func (s *Storage) GetStudyIDFromName(name string) (int, error) {
 	var study studyModel
@@ -151,6 +198,7 @@ func (s *Storage) GetAllStudySummaries() ([]goptuna.StudySummary, error) {
 	var err error
 	var studies []studyModel
 	err = s.db.
+		Preload("Directions").
 		Preload("UserAttributes").
 		Preload("SystemAttributes").
 		Preload("Trials").
@@ -346,12 +394,24 @@ func (s *Storage) CloneTrial(studyID int, baseTrial goptuna.FrozenTrial) (int, e
 			}
 		}
 
-		// intermediate values
-		for step := range baseTrial.IntermediateValues {
+		// values
+		for objective := range baseTrial.Values {
 			err := tx.Create(&trialValueModel{
 				TrialValueReferTrial: trial.ID,
-				Step:                 step,
-				Value:                baseTrial.IntermediateValues[step],
+				Objective:            objective,
+				Value:                baseTrial.Values[objective],
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		// intermediate values
+		for step := range baseTrial.IntermediateValues {
+			err := tx.Create(&trialIntermediateValueModel{
+				IntermediateValueReferTrial: trial.ID,
+				Step:                        step,
+				IntermediateValue:           baseTrial.IntermediateValues[step],
 			}).Error
 			if err != nil {
 				return err
@@ -396,6 +456,15 @@ func (s *Storage) CloneTrial(studyID int, baseTrial goptuna.FrozenTrial) (int, e
 
 // SetTrialValue sets the value of trial.
 func (s *Storage) SetTrialValue(trialID int, value float64) error {
+	return s.SetTrialValues(trialID, []float64{value})
+}
+
+// SetTrialValues sets the values of trial for multi-objective optimization.
+func (s *Storage) SetTrialValues(trialID int, values []float64) error {
+	if len(values) == 0 {
+		return errors.New("values must contain one or more elements")
+	}
+
 	return s.db.Transaction(func(tx *gorm.DB) error {
 		var trial trialModel
 		err := tx.First(&trial, "trial_id = ?", trialID).Error
@@ -412,12 +481,30 @@ func (s *Storage) SetTrialValue(trialID int, value float64) error {
 			return goptuna.ErrTrialCannotBeUpdated
 		}
 
+		// 'trials.value' column holds the value of the first objective
+		// to keep the compatibility with older versions of Goptuna.
 		err = tx.Model(&trialModel{}).
 			Where("trial_id = ?", trialID).
-			Update("value", value).Error
+			Update("value", values[0]).Error
 		if err != nil {
 			return err
 		}
+
+		err = tx.Where("trial_id = ?", trialID).
+			Delete(&trialValueModel{}).Error
+		if err != nil {
+			return err
+		}
+		for i := range values {
+			err = tx.Create(&trialValueModel{
+				TrialValueReferTrial: trialID,
+				Objective:            i,
+				Value:                values[i],
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
 		return nil
 	})
 }
@@ -441,16 +528,16 @@ func (s *Storage) SetTrialIntermediateValue(trialID int, step int, value float64
 		}
 
 		// If trial value is already exist, then do rollback.
-		err = tx.First(&trialValueModel{}, "trial_id = ? AND step = ?", trialID, step).Error
+		err = tx.First(&trialIntermediateValueModel{}, "trial_id = ? AND step = ?", trialID, step).Error
 		if !errors.Is(err, gorm.ErrRecordNotFound) {
 			return err
 		}
 
 		// Set trial intermediate value
-		err = tx.Create(&trialValueModel{
-			TrialValueReferTrial: trialID,
-			Step:                 step,
-			Value:                value,
+		err = tx.Create(&trialIntermediateValueModel{
+			IntermediateValueReferTrial: trialID,
+			Step:                        step,
+			IntermediateValue:           value,
 		}).Error
 		if err != nil {
 			return err
@@ -557,6 +644,33 @@ func (s *Storage) SetTrialSystemAttr(trialID int, key string, value string) erro
 	}).FirstOrCreate(&result).Error
 }
 
+// RecordHeartbeat records the heartbeat of the running trial.
+func (s *Storage) RecordHeartbeat(trialID int) error {
+	var result trialHeartbeatModel
+	return s.db.Where(&trialHeartbeatModel{
+		HeartbeatReferTrial: trialID,
+	}).Assign(&trialHeartbeatModel{
+		HeartbeatReferTrial: trialID,
+		Heartbeat:           time.Now(),
+	}).FirstOrCreate(&result).Error
+}
+
+// GetStaleTrialIDs returns IDs of the running trials whose heartbeat is expired.
+func (s *Storage) GetStaleTrialIDs(studyID int, gracePeriod time.Duration) ([]int, error) {
+	var trialIDs []int
+	err := s.db.Model(&trialHeartbeatModel{}).
+		Joins("JOIN trials ON trials.trial_id = trial_heartbeats.trial_id").
+		Where("trials.study_id = ?", studyID).
+		Where("trials.state = ?", trialStateRunning).
+		Where("trial_heartbeats.heartbeat < ?", time.Now().Add(-gracePeriod)).
+		Order("trial_heartbeats.trial_id").
+		Pluck("trial_heartbeats.trial_id", &trialIDs).Error
+	if err != nil {
+		return nil, err
+	}
+	return trialIDs, nil
+}
+
 // GetTrialNumberFromID returns the trial's number.
 func (s *Storage) GetTrialNumberFromID(trialID int) (int, error) {
 	trial, err := s.GetTrial(trialID)
@@ -664,6 +778,7 @@ func (s *Storage) GetAllTrials(studyID int) ([]goptuna.FrozenTrial, error) {
 	var trials []trialModel
 	var params []trialParamModel
 	var values []trialValueModel
+	var intermediateValues []trialIntermediateValueModel
 	var userAttrs []trialUserAttributeModel
 	var systemAttrs []trialSystemAttributeModel
 	if err := s.db.
@@ -682,6 +797,11 @@ func (s *Storage) GetAllTrials(studyID int) ([]goptuna.FrozenTrial, error) {
 		Find(&values, "trials.study_id = ?", studyID).Error; err != nil {
 		return nil, err
 	}
+	if err := s.db.
+		Joins("JOIN trials ON trial_intermediate_values.trial_id = trials.trial_id").
+		Find(&intermediateValues, "trials.study_id = ?", studyID).Error; err != nil {
+		return nil, err
+	}
 	if err := s.db.
 		Joins("JOIN trials ON trial_user_attributes.trial_id = trials.trial_id").
 		Find(&userAttrs, "trials.study_id = ?", studyID).Error; err != nil {
@@ -701,15 +821,17 @@ func (s *Storage) GetAllTrials(studyID int) ([]goptuna.FrozenTrial, error) {
 	// 	Preload("SystemAttributes").
 	// 	Preload("TrialParams").
 	// 	Preload("TrialValues").
+	// 	Preload("TrialIntermediateValues").
 	// 	Find(&trials).Error
 
-	return s.mergeTrialsORM(trials, params, values, userAttrs, systemAttrs)
+	return s.mergeTrialsORM(trials, params, values, intermediateValues, userAttrs, systemAttrs)
 }
 
 func (s *Storage) mergeTrialsORM(
 	trials []trialModel,
 	params []trialParamModel,
 	values []trialValueModel,
+	intermediateValues []trialIntermediateValueModel,
 	userAttrs []trialUserAttributeModel,
 	systemAttrs []trialSystemAttributeModel,
 ) ([]goptuna.FrozenTrial, error) {
@@ -737,6 +859,15 @@ func (s *Storage) mergeTrialsORM(
 		}
 		idToValues[trialID] = append(l, values[i])
 	}
+	idToIntermediateValues := make(map[int][]trialIntermediateValueModel, len(trials))
+	for i := range intermediateValues {
+		trialID := intermediateValues[i].IntermediateValueReferTrial
+		l, ok := idToIntermediateValues[trialID]
+		if !ok {
+			idToIntermediateValues[trialID] = make([]trialIntermediateValueModel, 0, defaultSize)
+		}
+		idToIntermediateValues[trialID] = append(l, intermediateValues[i])
+	}
 	idToUserAttrs := make(map[int][]trialUserAttributeModel, len(trials))
 	for i := range userAttrs {
 		trialID := userAttrs[i].UserAttributeReferTrial
@@ -764,6 +895,9 @@ func (s *Storage) mergeTrialsORM(
 		if v, ok := idToValues[trials[i].ID]; ok {
 			trials[i].TrialValues = v
 		}
+		if v, ok := idToIntermediateValues[trials[i].ID]; ok {
+			trials[i].TrialIntermediateValues = v
+		}
 		if v, ok := idToUserAttrs[trials[i].ID]; ok {
 			trials[i].UserAttributes = v
 		}
@@ -789,6 +923,18 @@ func (s *Storage) GetStudyDirection(studyID int) (goptuna.StudyDirection, error)
 	return toGoptunaStudyDirection(study.Direction), nil
 }
 
+// GetStudyDirections returns study directions of the objectives.
+func (s *Storage) GetStudyDirections(studyID int) ([]goptuna.StudyDirection, error) {
+	var study studyModel
+	err := s.db.
+		Preload("Directions").
+		First(&study, "study_id = ?", studyID).Error
+	if err != nil {
+		return nil, err
+	}
+	return toGoptunaStudyDirections(study), nil
+}
+
 // GetTrial returns Trial.
 func (s *Storage) GetTrial(trialID int) (goptuna.FrozenTrial, error) {
 	var trial trialModel
@@ -797,6 +943,7 @@ func (s *Storage) GetTrial(trialID int) (goptuna.FrozenTrial, error) {
 		Preload("SystemAttributes").
 		Preload("TrialParams").
 		Preload("TrialValues").
+		Preload("TrialIntermediateValues").
 		First(&trial, "trial_id = ?", trialID).Error
 	if err != nil {
 		return goptuna.FrozenTrial{}, err
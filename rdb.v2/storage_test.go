@@ -167,6 +167,105 @@ func TestStorage_StudyDirection(t *testing.T) {
 	}
 }
 
+func TestStorage_StudyDirections(t *testing.T) {
+	s, teardown, err := SetupSQLite3Test()
+	if err != nil {
+		t.Errorf("failed to setup tests with %s", err)
+		return
+	}
+	defer teardown()
+
+	studyID, err := s.CreateNewStudy("study")
+	if err != nil {
+		t.Errorf("error: %v != nil", err)
+		return
+	}
+
+	directions, err := s.GetStudyDirections(studyID)
+	if err != nil {
+		t.Errorf("error: %v != nil", err)
+		return
+	}
+	if !reflect.DeepEqual(directions, []goptuna.StudyDirection{goptuna.StudyDirectionMinimize}) {
+		t.Errorf("want [minimize], but got %v", directions)
+		return
+	}
+
+	expected := []goptuna.StudyDirection{
+		goptuna.StudyDirectionMaximize,
+		goptuna.StudyDirectionMinimize,
+	}
+	err = s.SetStudyDirections(studyID, expected)
+	if err != nil {
+		t.Errorf("error: %v != nil", err)
+		return
+	}
+
+	directions, err = s.GetStudyDirections(studyID)
+	if err != nil {
+		t.Errorf("error: %v != nil", err)
+		return
+	}
+	if !reflect.DeepEqual(directions, expected) {
+		t.Errorf("want %v, but got %v", expected, directions)
+		return
+	}
+
+	direction, err := s.GetStudyDirection(studyID)
+	if err != nil {
+		t.Errorf("error: %v != nil", err)
+		return
+	}
+	if direction != goptuna.StudyDirectionMaximize {
+		t.Errorf("want %s, but got %s", goptuna.StudyDirectionMaximize, direction)
+	}
+}
+
+func TestStorage_SetTrialValues(t *testing.T) {
+	s, teardown, err := SetupSQLite3Test()
+	if err != nil {
+		t.Errorf("failed to setup tests with %s", err)
+		return
+	}
+	defer teardown()
+
+	studyID, err := s.CreateNewStudy("")
+	if err != nil {
+		t.Errorf("error: %v != nil", err)
+		return
+	}
+	trialID, err := s.CreateNewTrial(studyID)
+	if err != nil {
+		t.Errorf("error: %v != nil", err)
+		return
+	}
+	err = s.SetTrialIntermediateValue(trialID, 1, 0.5)
+	if err != nil {
+		t.Errorf("error: %v != nil", err)
+		return
+	}
+	err = s.SetTrialValues(trialID, []float64{0.1, 0.2})
+	if err != nil {
+		t.Errorf("error: %v != nil", err)
+		return
+	}
+
+	trials, err := s.GetAllTrials(studyID)
+	if err != nil {
+		t.Errorf("error: %v != nil", err)
+		return
+	}
+	if !reflect.DeepEqual(trials[0].Values, []float64{0.1, 0.2}) {
+		t.Errorf("want [0.1 0.2], but got %v", trials[0].Values)
+	}
+	if trials[0].Value != 0.1 {
+		t.Errorf("want 0.1, but got %f", trials[0].Value)
+	}
+	if !reflect.DeepEqual(trials[0].IntermediateValues, map[int]float64{1: 0.5}) {
+		t.Errorf("want map[1:0.5], but got %v", trials[0].IntermediateValues)
+	}
+}
+
 func TestStorage_StudyUserAttrs(t *testing.T) {
 	s, teardown, err := SetupSQLite3Test()
 	if err != nil {
@@ -229,6 +328,41 @@ func TestStorage_StudySystemAttrs(t *testing.T) {
 	}
 }
 
+func TestStorage_CreateStudySystemAttr(t *testing.T) {
+	s, teardown, err := SetupSQLite3Test()
+	if err != nil {
+		t.Errorf("failed to setup tests with %s", err)
+		return
+	}
+	defer teardown()
+
+	studyID, err := s.CreateNewStudy("")
+	if err != nil {
+		t.Errorf("error: %v != nil", err)
+		return
+	}
+
+	for i, want := range []bool{true, false} {
+		created, err := s.CreateStudySystemAttr(studyID, "key", fmt.Sprintf("value%d", i))
+		if err != nil {
+			t.Errorf("error: %v != nil", err)
+			return
+		}
+		if created != want {
+			t.Errorf("created should be %v, but got %v", want, created)
+		}
+	}
+	got, err := s.GetStudySystemAttrs(studyID)
+	if err != nil {
+		t.Errorf("error: %v != nil", err)
+		return
+	}
+	want := map[string]string{"key": "value0"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %#v, but got %#v", want, got)
+	}
+}
+
 func TestStorage_TrialUserAttrs(t *testing.T) {
 	s, teardown, err := SetupSQLite3Test()
 	if err != nil {
@@ -416,6 +550,63 @@ func TestStorage_SetTrialState(t *testing.T) {
 	}
 }
 
+func TestStorage_GetStaleTrialIDs(t *testing.T) {
+	s, teardown, err := SetupSQLite3Test()
+	if err != nil {
+		t.Errorf("failed to setup tests with %s", err)
+		return
+	}
+	defer teardown()
+
+	studyID, err := s.CreateNewStudy("")
+	if err != nil {
+		t.Errorf("error: %v != nil", err)
+		return
+	}
+	var trialIDs []int
+	for i := 0; i < 3; i++ {
+		trialID, err := s.CreateNewTrial(studyID)
+		if err != nil {
+			t.Errorf("error: %v != nil", err)
+			return
+		}
+		trialIDs = append(trialIDs, trialID)
+	}
+	for _, trialID := range trialIDs[:2] {
+		if err = s.RecordHeartbeat(trialID); err != nil {
+			t.Errorf("error: %v != nil", err)
+			return
+		}
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if err = s.RecordHeartbeat(trialIDs[1]); err != nil {
+		t.Errorf("error: %v != nil", err)
+		return
+	}
+	got, err := s.GetStaleTrialIDs(studyID, 50*time.Millisecond)
+	if err != nil {
+		t.Errorf("error: %v != nil", err)
+		return
+	}
+	if !reflect.DeepEqual(got, []int{trialIDs[0]}) {
+		t.Errorf("GetStaleTrialIDs() = %v, want %v", got, []int{trialIDs[0]})
+	}
+
+	if err = s.SetTrialState(trialIDs[0], goptuna.TrialStateFail); err != nil {
+		t.Errorf("error: %v != nil", err)
+		return
+	}
+	got, err = s.GetStaleTrialIDs(studyID, 50*time.Millisecond)
+	if err != nil {
+		t.Errorf("error: %v != nil", err)
+		return
+	}
+	if len(got) != 0 {
+		t.Errorf("GetStaleTrialIDs() = %v, want empty", got)
+	}
+}
+
 func TestStorage_GetTrial(t *testing.T) {
 	s, teardown, err := SetupSQLite3Test()
 	if err != nil {
@@ -652,13 +843,19 @@ func TestStorage_CloneTrial(t *testing.T) {
 		DatetimeStart:    now,
 		DatetimeComplete: now,
 		InternalParams: map[string]float64{
-			"x": 0.5,
+			"x":          0.5,
+			"batch_size": 1,
+			"dropout":    2,
 		},
 		Params: map[string]interface{}{
-			"x": 0.5,
+			"x":          0.5,
+			"batch_size": 32,
+			"dropout":    0.5,
 		},
 		Distributions: map[string]interface{}{
-			"x": goptuna.UniformDistribution{High: 1, Low: 0},
+			"x":          goptuna.UniformDistribution{High: 1, Low: 0},
+			"batch_size": goptuna.CategoricalDistribution{Choices: []interface{}{16, 32, 64}},
+			"dropout":    goptuna.CategoricalDistribution{Choices: []interface{}{nil, false, 0.5, 1.0, "auto"}},
 		},
 		UserAttrs: map[string]string{
 			"foo": "bar",
@@ -733,3 +930,64 @@ func TestStorage_CloneTrial(t *testing.T) {
 		t.Errorf("DatetimeComplete should be %s, but got %s", trials[0].DatetimeComplete, baseTrial.DatetimeComplete)
 	}
 }
+
+func TestCopyStudy(t *testing.T) {
+	s, teardown, err := SetupSQLite3Test()
+	if err != nil {
+		t.Errorf("failed to setup tests with %s", err)
+		return
+	}
+	defer teardown()
+
+	fromStorage := goptuna.NewInMemoryStorage()
+	study, err := goptuna.CreateStudy(
+		"example",
+		goptuna.StudyOptionStorage(fromStorage),
+		goptuna.StudyOptionLogger(nil),
+	)
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	err = study.Optimize(func(trial goptuna.Trial) (float64, error) {
+		x, _ := trial.SuggestFloat("x", -10, 10)
+		n, _ := trial.SuggestInt("n", 1, 8, goptuna.SuggestIntOptionLog(true))
+		_ = trial.SetUserAttr("foo", "bar")
+		_ = trial.Report(1, x)
+		return x * float64(n), nil
+	}, 3)
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+
+	err = goptuna.CopyStudy(fromStorage, "example", s, "copied")
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	studyID, err := s.GetStudyIDFromName("copied")
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	expected, _ := study.GetTrials()
+	actual, err := s.GetAllTrials(studyID)
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	if len(actual) != len(expected) {
+		t.Errorf("should be %d trials, but got %d", len(expected), len(actual))
+		return
+	}
+	for i := range actual {
+		if actual[i].Value != expected[i].Value ||
+			!reflect.DeepEqual(actual[i].Params, expected[i].Params) ||
+			!reflect.DeepEqual(actual[i].Distributions, expected[i].Distributions) ||
+			!reflect.DeepEqual(actual[i].IntermediateValues, expected[i].IntermediateValues) ||
+			!reflect.DeepEqual(actual[i].UserAttrs, expected[i].UserAttrs) {
+			t.Errorf("trial should be copied: %#v != %#v", actual[i], expected[i])
+		}
+	}
+}
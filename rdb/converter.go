@@ -1,6 +1,7 @@
 package rdb
 
 import (
+	"encoding/json"
 	"errors"
 	"time"
 
@@ -58,12 +59,23 @@ func toFrozenTrial(trial trialModel) (goptuna.FrozenTrial, error) {
 		intermediateValue[trial.TrialValues[i].Step] = trial.TrialValues[i].Value
 	}
 
+	var values []float64
+	if j, ok := systemAttrs[trialValuesAttrKey]; ok {
+		err = json.Unmarshal([]byte(j), &values)
+		if err != nil {
+			return goptuna.FrozenTrial{}, err
+		}
+	} else if state == goptuna.TrialStateComplete {
+		values = []float64{trial.Value}
+	}
+
 	return goptuna.FrozenTrial{
 		ID:                 trial.ID,
 		StudyID:            trial.TrialReferStudy,
 		Number:             trial.Number,
 		State:              state,
 		Value:              trial.Value,
+		Values:             values,
 		IntermediateValues: intermediateValue,
 		DatetimeStart:      datetimeStart,
 		DatetimeComplete:   datetimeComplete,
@@ -85,10 +97,12 @@ func toStudySummary(study studyModel, bestTrial goptuna.FrozenTrial, start time.
 	for i := range study.SystemAttributes {
 		systemAttrs[study.SystemAttributes[i].Key] = decodeAttrValue(study.SystemAttributes[i].ValueJSON)
 	}
+	directions := toGoptunaStudyDirections(study)
 	return goptuna.StudySummary{
 		ID:            study.ID,
 		Name:          study.Name,
-		Direction:     toGoptunaStudyDirection(study.Direction),
+		Direction:     directions[0],
+		Directions:    directions,
 		BestTrial:     bestTrial,
 		UserAttrs:     userAttrs,
 		SystemAttrs:   systemAttrs,
@@ -142,3 +156,19 @@ func toGoptunaStudyDirection(direction string) goptuna.StudyDirection {
 		return goptuna.StudyDirectionMinimize
 	}
 }
+
+// toGoptunaStudyDirections returns the directions of the study. The directions
+// fall back to 'studies.direction' column if the system attribute is not found.
+func toGoptunaStudyDirections(study studyModel) []goptuna.StudyDirection {
+	for i := range study.SystemAttributes {
+		if study.SystemAttributes[i].Key != studyDirectionsAttrKey {
+			continue
+		}
+		var directions []goptuna.StudyDirection
+		err := json.Unmarshal([]byte(decodeAttrValue(study.SystemAttributes[i].ValueJSON)), &directions)
+		if err == nil && len(directions) > 0 {
+			return directions
+		}
+	}
+	return []goptuna.StudyDirection{toGoptunaStudyDirection(study.Direction)}
+}
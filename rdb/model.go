@@ -20,6 +20,15 @@ const (
 	trialStateWaiting  = "WAITING"
 )
 
+// This schema has no tables for the multiple objectives, so the directions of
+// a study and the values of a trial are stored as JSON in the system attributes.
+// They are encoded in Goptuna's representation such as ["minimize","maximize"],
+// which is not compatible with the 'optuna.multi_objective' module of Optuna.
+const (
+	studyDirectionsAttrKey = "multi_objective:study:directions"
+	trialValuesAttrKey     = "multi_objective:trial:values"
+)
+
 // https://gorm.io/docs/models.html
 
 type studyModel struct {
@@ -1,6 +1,8 @@
 package rdb
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -10,6 +12,7 @@ import (
 )
 
 var _ goptuna.Storage = &Storage{}
+var _ goptuna.StudySystemAttrCreator = &Storage{}
 
 // NewStorage returns new RDB storage.
 // Deprecated: Please use `github.com/c-bata/goptuna/rdb.v2` package.
@@ -62,6 +65,24 @@ func (s *Storage) SetStudyDirection(studyID int, direction goptuna.StudyDirectio
 	return err
 }
 
+// SetStudyDirections sets study directions of the multiple objectives.
+// The direction of the first objective is stored in 'studies.direction' column,
+// and all directions are stored in the study system attributes.
+func (s *Storage) SetStudyDirections(studyID int, directions []goptuna.StudyDirection) error {
+	if len(directions) == 0 {
+		return errors.New("directions must contain one or more elements")
+	}
+	err := s.SetStudyDirection(studyID, directions[0])
+	if err != nil {
+		return err
+	}
+	j, err := json.Marshal(directions)
+	if err != nil {
+		return err
+	}
+	return s.SetStudySystemAttr(studyID, studyDirectionsAttrKey, string(j))
+}
+
 // SetStudyUserAttr to store the value for the user.
 func (s *Storage) SetStudyUserAttr(studyID int, key string, value string) error {
 	var result studyUserAttributeModel
@@ -88,6 +109,28 @@ func (s *Storage) SetStudySystemAttr(studyID int, key string, value string) erro
 	}).FirstOrCreate(&result).Error
 }
 
+// CreateStudySystemAttr stores the value for the system only if the key doesn't exist.
+// The unique index of the study system attributes makes this atomic.
+func (s *Storage) CreateStudySystemAttr(studyID int, key string, value string) (bool, error) {
+	err := s.db.Create(&studySystemAttributeModel{
+		SystemAttributeReferStudy: studyID,
+		Key:                       key,
+		ValueJSON:                 encodeAttrValue(value),
+	}).Error
+	if err == nil {
+		return true, nil
+	}
+	// The insertion violates the unique index if the key already exists.
+	var count int
+	if cerr := s.db.Model(&studySystemAttributeModel{}).Where(&studySystemAttributeModel{
+		SystemAttributeReferStudy: studyID,
+		Key:                       key,
+	}).Count(&count).Error; cerr != nil || count == 0 {
+		return false, err
+	}
+	return false, nil
+}
+
 // GetStudyIDFromName return the study id from study name.
 func (s *Storage) GetStudyIDFromName(name string) (int, error) {
 	var study studyModel
@@ -321,6 +364,10 @@ func (s *Storage) CloneTrial(studyID int, baseTrial goptuna.FrozenTrial) (int, e
 
 	// system attrs
 	for key := range baseTrial.SystemAttrs {
+		if key == trialValuesAttrKey {
+			// Stored from baseTrial.Values below.
+			continue
+		}
 		err := tx.Create(&trialSystemAttributeModel{
 			SystemAttributeReferTrial: trial.ID,
 			Key:                       key,
@@ -332,6 +379,24 @@ func (s *Storage) CloneTrial(studyID int, baseTrial goptuna.FrozenTrial) (int, e
 		}
 	}
 
+	// values of the multiple objectives
+	if len(baseTrial.Values) > 0 {
+		j, err := json.Marshal(baseTrial.Values)
+		if err != nil {
+			tx.Rollback()
+			return -1, err
+		}
+		err = tx.Create(&trialSystemAttributeModel{
+			SystemAttributeReferTrial: trial.ID,
+			Key:                       trialValuesAttrKey,
+			ValueJSON:                 encodeAttrValue(string(j)),
+		}).Error
+		if err != nil {
+			tx.Rollback()
+			return -1, err
+		}
+	}
+
 	// intermediate values
 	for step := range baseTrial.IntermediateValues {
 		err := tx.Create(&trialValueModel{
@@ -419,6 +484,51 @@ func (s *Storage) SetTrialValue(trialID int, value float64) error {
 	return tx.Commit().Error
 }
 
+// SetTrialValues sets the values of trial for multi-objective optimization.
+// The value of the first objective is stored in 'trials.value' column,
+// and all values are stored in the trial system attributes.
+func (s *Storage) SetTrialValues(trialID int, values []float64) error {
+	if len(values) == 0 {
+		return errors.New("values must contain one or more elements")
+	}
+	j, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var trial trialModel
+		err := tx.First(&trial, "trial_id = ?", trialID).Error
+		if err != nil {
+			return err
+		}
+		state, err := toStateExternalRepresentation(trial.State)
+		if err != nil {
+			return err
+		}
+		if state.IsFinished() {
+			return goptuna.ErrTrialCannotBeUpdated
+		}
+
+		err = tx.Model(&trialModel{}).
+			Where("trial_id = ?", trialID).
+			Update("value", values[0]).Error
+		if err != nil {
+			return err
+		}
+
+		var result trialSystemAttributeModel
+		return tx.Where(&trialSystemAttributeModel{
+			SystemAttributeReferTrial: trialID,
+			Key:                       trialValuesAttrKey,
+		}).Assign(&trialSystemAttributeModel{
+			SystemAttributeReferTrial: trialID,
+			Key:                       trialValuesAttrKey,
+			ValueJSON:                 encodeAttrValue(string(j)),
+		}).FirstOrCreate(&result).Error
+	})
+}
+
 // SetTrialIntermediateValue sets the intermediate value of trial.
 // While sets the intermediate value, trial.value is also updated.
 func (s *Storage) SetTrialIntermediateValue(trialID int, step int, value float64) error {
@@ -816,6 +926,18 @@ func (s *Storage) GetStudyDirection(studyID int) (goptuna.StudyDirection, error)
 	return toGoptunaStudyDirection(study.Direction), nil
 }
 
+// GetStudyDirections returns study directions of the objectives.
+func (s *Storage) GetStudyDirections(studyID int) ([]goptuna.StudyDirection, error) {
+	var study studyModel
+	err := s.db.
+		Preload("SystemAttributes").
+		First(&study, "study_id = ?", studyID).Error
+	if err != nil {
+		return nil, err
+	}
+	return toGoptunaStudyDirections(study), nil
+}
+
 // GetTrial returns Trial.
 func (s *Storage) GetTrial(trialID int) (goptuna.FrozenTrial, error) {
 	var trial trialModel
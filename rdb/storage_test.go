@@ -1,6 +1,7 @@
 package rdb_test
 
 import (
+	"fmt"
 	"os"
 	"reflect"
 	"testing"
@@ -227,6 +228,42 @@ func TestStorage_StudySystemAttrs(t *testing.T) {
 	}
 }
 
+func TestStorage_CreateStudySystemAttr(t *testing.T) {
+	db, teardown, err := SetupSQLite3Test(t, "goptuna-test.db")
+	defer teardown()
+	if err != nil {
+		t.Errorf("failed to setup tests with %s", err)
+		return
+	}
+
+	storage := rdb.NewStorage(db)
+	studyID, err := storage.CreateNewStudy("")
+	if err != nil {
+		t.Errorf("error: %v != nil", err)
+		return
+	}
+
+	for i, want := range []bool{true, false} {
+		created, err := storage.CreateStudySystemAttr(studyID, "key", fmt.Sprintf("value%d", i))
+		if err != nil {
+			t.Errorf("error: %v != nil", err)
+			return
+		}
+		if created != want {
+			t.Errorf("created should be %v, but got %v", want, created)
+		}
+	}
+	got, err := storage.GetStudySystemAttrs(studyID)
+	if err != nil {
+		t.Errorf("error: %v != nil", err)
+		return
+	}
+	want := map[string]string{"key": "value0"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %#v, but got %#v", want, got)
+	}
+}
+
 func TestStorage_TrialUserAttrs(t *testing.T) {
 	db, teardown, err := SetupSQLite3Test(t, "goptuna-test.db")
 	defer teardown()
@@ -740,3 +777,124 @@ func TestStorage_CloneTrial(t *testing.T) {
 		t.Errorf("DatetimeComplete should be %s, but got %s", trials[0].DatetimeComplete, baseTrial.DatetimeComplete)
 	}
 }
+
+func TestStorage_MultiObjective(t *testing.T) {
+	db, teardown, err := SetupSQLite3Test(t, "goptuna-test.db")
+	defer teardown()
+	if err != nil {
+		t.Errorf("failed to setup tests with %s", err)
+		return
+	}
+
+	storage := rdb.NewStorage(db)
+	studyID, err := storage.CreateNewStudy("")
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	directions := []goptuna.StudyDirection{
+		goptuna.StudyDirectionMinimize,
+		goptuna.StudyDirectionMaximize,
+	}
+	err = storage.SetStudyDirections(studyID, directions)
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	gotDirections, err := storage.GetStudyDirections(studyID)
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	if !reflect.DeepEqual(gotDirections, directions) {
+		t.Errorf("directions should be %v, but got %v", directions, gotDirections)
+	}
+
+	trialID, err := storage.CreateNewTrial(studyID)
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	err = storage.SetTrialValues(trialID, []float64{0.1, 0.2})
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	err = storage.SetTrialState(trialID, goptuna.TrialStateComplete)
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+
+	now := time.Now()
+	clonedID, err := storage.CloneTrial(studyID, goptuna.FrozenTrial{
+		State:              goptuna.TrialStateComplete,
+		Value:              0.3,
+		Values:             []float64{0.3, 0.4},
+		IntermediateValues: map[int]float64{},
+		DatetimeStart:      now,
+		DatetimeComplete:   now,
+		InternalParams:     map[string]float64{},
+		Params:             map[string]interface{}{},
+		Distributions:      map[string]interface{}{},
+		UserAttrs:          map[string]string{},
+		SystemAttrs:        map[string]string{},
+	})
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+
+	for id, want := range map[int][]float64{
+		trialID:  {0.1, 0.2},
+		clonedID: {0.3, 0.4},
+	} {
+		trial, err := storage.GetTrial(id)
+		if err != nil {
+			t.Errorf("should be nil, but got %s", err)
+			return
+		}
+		if !reflect.DeepEqual(trial.Values, want) {
+			t.Errorf("values of trial %d should be %v, but got %v", id, want, trial.Values)
+		}
+		if trial.Value != want[0] {
+			t.Errorf("value of trial %d should be %f, but got %f", id, want[0], trial.Value)
+		}
+	}
+
+	// The system attributes used to store the directions and the values
+	// must not be copied to the other storages.
+	studyName, err := storage.GetStudyNameFromID(studyID)
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	toStorage := goptuna.NewInMemoryStorage()
+	err = goptuna.CopyStudy(storage, studyName, toStorage, "copied")
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	copied, err := goptuna.LoadStudy("copied",
+		goptuna.StudyOptionStorage(toStorage), goptuna.StudyOptionLogger(nil))
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	if !reflect.DeepEqual(copied.Directions(), directions) {
+		t.Errorf("directions should be %v, but got %v", directions, copied.Directions())
+	}
+	if attrs, _ := copied.GetSystemAttrs(); len(attrs) != 0 {
+		t.Errorf("study system attrs should be empty, but got %v", attrs)
+	}
+	trials, err := copied.GetTrials()
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	for _, trial := range trials {
+		if len(trial.SystemAttrs) != 0 {
+			t.Errorf("trial system attrs should be empty, but got %v", trial.SystemAttrs)
+		}
+	}
+}
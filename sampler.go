@@ -2,8 +2,8 @@ package goptuna
 
 import (
 	"errors"
-	"math"
 	"math/rand"
+	"reflect"
 	"sync"
 )
 
@@ -32,6 +32,15 @@ type RelativeSampler interface {
 	SampleRelative(*Study, FrozenTrial, map[string]interface{}) (map[string]float64, error)
 }
 
+// RelativeSearchSpaceInferrer is an optional interface for RelativeSampler to infer
+// the search space passed to SampleRelative by itself. If the relative sampler
+// doesn't implement this interface, IntersectionSearchSpace is used instead.
+// Note that the search space defined by StudyOptionDefineSearchSpace takes precedence.
+type RelativeSearchSpaceInferrer interface {
+	// InferRelativeSearchSpace returns the search space for SampleRelative.
+	InferRelativeSearchSpace(*Study, FrozenTrial) (map[string]interface{}, error)
+}
+
 // IntersectionSearchSpace return return the intersection search space of the Study.
 //
 // Intersection search space contains the intersection of parameter distributions that have been
@@ -74,7 +83,7 @@ func IntersectionSearchSpace(study *Study) (map[string]interface{}, error) {
 		for name := range searchSpace {
 			if !exists(name) {
 				deleteParams = append(deleteParams, name)
-			} else if trials[i].Distributions[name] != searchSpace[name] {
+			} else if !reflect.DeepEqual(trials[i].Distributions[name], searchSpace[name]) {
 				deleteParams = append(deleteParams, name)
 			}
 		}
@@ -122,53 +131,14 @@ func (s *RandomSampler) Sample(
 	paramName string,
 	paramDistribution interface{},
 ) (float64, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	switch d := paramDistribution.(type) {
-	case UniformDistribution:
-		if d.Single() {
-			return d.Low, nil
-		}
-		return s.rng.Float64()*(d.High-d.Low) + d.Low, nil
-	case LogUniformDistribution:
-		if d.Single() {
-			return d.Low, nil
-		}
-		logLow := math.Log(d.Low)
-		logHigh := math.Log(d.High)
-		return math.Exp(s.rng.Float64()*(logHigh-logLow) + logLow), nil
-	case IntUniformDistribution:
-		if d.Single() {
-			return float64(d.Low), nil
-		}
-		return float64(s.rng.Intn(d.High-d.Low) + d.Low), nil
-	case StepIntUniformDistribution:
-		if d.Single() {
-			return float64(d.Low), nil
-		}
-		r := (d.High - d.Low) / d.Step
-		v := (s.rng.Intn(r) * d.Step) + d.Low
-		return float64(v), nil
-	case DiscreteUniformDistribution:
-		if d.Single() {
-			return d.Low, nil
-		}
-		q := d.Q
-		r := d.High - d.Low
-		// [low, high] is shifted to [0, r] to align sampled values at regular intervals.
-		low := 0 - 0.5*q
-		high := r + 0.5*q
-		x := s.rng.Float64()*(high-low) + low
-		v := math.Round(x/q)*q + d.Low
-		return math.Min(math.Max(v, d.Low), d.High), nil
-	case CategoricalDistribution:
-		if d.Single() {
-			return float64(0), nil
-		}
-		return float64(rand.Intn(len(d.Choices))), nil
-	default:
+	d, ok := paramDistribution.(Distribution)
+	if !ok {
 		return 0.0, errors.New("undefined distribution")
 	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return d.Sample(s.rng), nil
 }
 
 // RandomSearchSampler for random search
@@ -188,3 +158,56 @@ var (
 	// Deprecated: this is renamed to NewRandomSampler.
 	NewRandomSearchSampler = NewRandomSampler
 )
+
+// GroupDecomposedSearchSpace returns the search spaces of the Study decomposed into the groups.
+//
+// The parameters suggested in the completed or pruned trials are partitioned into
+// the groups whose parameters always appear together in each trial, so that each
+// branch of the conditional search space belongs to its own group.
+// If a parameter has different distributions in the trials, the latest one is used.
+func GroupDecomposedSearchSpace(study *Study) ([]map[string]interface{}, error) {
+	var groups []map[string]interface{}
+
+	trials, err := study.GetTrials()
+	if err == ErrTrialsPartiallyDeleted {
+		study.logger.Warn("Some trials are not used to calculate group decomposed search spaces." +
+			" Please use `goptuna.StudyOptionDefineSearchSpace` option.")
+		err = nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	for i := range trials {
+		if trials[i].State != TrialStateComplete && trials[i].State != TrialStatePruned {
+			continue
+		}
+
+		remaining := make(map[string]interface{}, len(trials[i].Distributions))
+		for name, distribution := range trials[i].Distributions {
+			remaining[name] = distribution
+		}
+		next := make([]map[string]interface{}, 0, 2*len(groups)+1)
+		for _, group := range groups {
+			intersection := make(map[string]interface{}, len(group))
+			difference := make(map[string]interface{}, len(group))
+			for name, distribution := range group {
+				if latest, ok := remaining[name]; ok {
+					intersection[name] = latest
+					delete(remaining, name)
+				} else {
+					difference[name] = distribution
+				}
+			}
+			next = append(next, intersection, difference)
+		}
+		next = append(next, remaining)
+
+		groups = groups[:0]
+		for _, group := range next {
+			if len(group) > 0 {
+				groups = append(groups, group)
+			}
+		}
+	}
+	return groups, nil
+}
@@ -452,3 +452,66 @@ func TestIntersectionSearchSpace(t *testing.T) {
 		})
 	}
 }
+
+func TestGroupDecomposedSearchSpace(t *testing.T) {
+	study, err := goptuna.CreateStudy("sampler_test", goptuna.StudyOptionLogger(nil))
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	groups, err := goptuna.GroupDecomposedSearchSpace(study)
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	if len(groups) != 0 {
+		t.Errorf("should be empty, but got %v", groups)
+		return
+	}
+
+	// The first trial
+	if err = study.Optimize(func(trial goptuna.Trial) (float64, error) {
+		_, _ = trial.SuggestCategorical("model", []string{"svm", "rf"})
+		_, _ = trial.SuggestLogFloat("svm_c", 1e-5, 1e5)
+		_, _ = trial.SuggestFloat("svm_gamma", 0, 1)
+		return 0, nil
+	}, 1); err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	// The second trial with the other branch
+	if err = study.Optimize(func(trial goptuna.Trial) (float64, error) {
+		_, _ = trial.SuggestCategorical("model", []string{"svm", "rf"})
+		_, _ = trial.SuggestInt("rf_max_depth", 2, 32)
+		return 0, goptuna.ErrTrialPruned
+	}, 1); err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	// Failed trial is ignored
+	_ = study.Optimize(func(trial goptuna.Trial) (float64, error) {
+		_, _ = trial.SuggestFloat("svm_gamma", 0, 1)
+		return 0, errors.New("something error")
+	}, 1)
+
+	groups, err = goptuna.GroupDecomposedSearchSpace(study)
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	want := []map[string]interface{}{
+		{
+			"model": goptuna.CategoricalDistribution{Choices: []interface{}{"svm", "rf"}},
+		},
+		{
+			"svm_c":     goptuna.LogUniformDistribution{Low: 1e-5, High: 1e5},
+			"svm_gamma": goptuna.UniformDistribution{Low: 0, High: 1},
+		},
+		{
+			"rf_max_depth": goptuna.IntUniformDistribution{Low: 2, High: 32},
+		},
+	}
+	if !reflect.DeepEqual(groups, want) {
+		t.Errorf("GroupDecomposedSearchSpace() = %v, want %v", groups, want)
+	}
+}
@@ -80,6 +80,36 @@ func (s *Sampler) SampleRelative(study *goptuna.Study, trial goptuna.FrozenTrial
 			params[name] = float64(v)
 		case goptuna.CategoricalDistribution:
 			params[name] = math.Floor(points[i] * float64(len(d.Choices)))
+		case goptuna.FloatDistribution:
+			low, high := d.Low, d.High
+			if d.Step > 0 && !d.Log {
+				low -= 0.5 * d.Step
+				high += 0.5 * d.Step
+			}
+			var x float64
+			if d.Log {
+				logLow := math.Log(low)
+				logHigh := math.Log(high)
+				x = math.Exp(points[i]*(logHigh-logLow) + logLow)
+			} else {
+				x = points[i]*(high-low) + low
+			}
+			params[name] = d.ToExternalRepr(x).(float64)
+		case goptuna.IntDistribution:
+			step := float64(d.Step)
+			if step <= 0 || d.Log {
+				step = 1
+			}
+			low, high := float64(d.Low)-0.5*step, float64(d.High)+0.5*step
+			var x float64
+			if d.Log {
+				logLow := math.Log(low)
+				logHigh := math.Log(high)
+				x = math.Exp(points[i]*(logHigh-logLow) + logLow)
+			} else {
+				x = points[i]*(high-low) + low
+			}
+			params[name] = float64(d.ToExternalRepr(x).(int))
 		default:
 			return nil, goptuna.ErrUnknownDistribution
 		}
@@ -2,6 +2,7 @@ package goptuna
 
 import (
 	"errors"
+	"sort"
 	"sync"
 	"time"
 )
@@ -17,6 +18,7 @@ type Storage interface {
 	CreateNewStudy(name string) (int, error)
 	DeleteStudy(studyID int) error
 	SetStudyDirection(studyID int, direction StudyDirection) error
+	SetStudyDirections(studyID int, directions []StudyDirection) error
 	SetStudyUserAttr(studyID int, key string, value string) error
 	SetStudySystemAttr(studyID int, key string, value string) error
 	// Basic study access
@@ -24,6 +26,7 @@ type Storage interface {
 	GetStudyIDFromTrialID(trialID int) (int, error)
 	GetStudyNameFromID(studyID int) (string, error)
 	GetStudyDirection(studyID int) (StudyDirection, error)
+	GetStudyDirections(studyID int) ([]StudyDirection, error)
 	GetStudyUserAttrs(studyID int) (map[string]string, error)
 	GetStudySystemAttrs(studyID int) (map[string]string, error)
 	GetAllStudySummaries() ([]StudySummary, error)
@@ -31,6 +34,7 @@ type Storage interface {
 	CreateNewTrial(studyID int) (int, error)
 	CloneTrial(studyID int, baseTrial FrozenTrial) (int, error)
 	SetTrialValue(trialID int, value float64) error
+	SetTrialValues(trialID int, values []float64) error
 	SetTrialIntermediateValue(trialID int, step int, value float64) error
 	SetTrialParam(trialID int, paramName string, paramValueInternal float64,
 		distribution interface{}) error
@@ -48,7 +52,18 @@ type Storage interface {
 	GetTrialSystemAttrs(trialID int) (map[string]string, error)
 }
 
+// StudySystemAttrCreator is an optional interface of Storage to create the
+// system attribute of the study atomically. It's used to prevent the workers
+// from enqueueing the same parameters by EnqueueTrialOptionSkipIfExists.
+type StudySystemAttrCreator interface {
+	// CreateStudySystemAttr stores the value only if the key doesn't exist,
+	// then returns true if the value is stored.
+	CreateStudySystemAttr(studyID int, key string, value string) (bool, error)
+}
+
 var _ Storage = &InMemoryStorage{}
+var _ HeartbeatStorage = &InMemoryStorage{}
+var _ StudySystemAttrCreator = &InMemoryStorage{}
 
 // InMemoryStorageStudyID is a study id for in memory storage backend.
 const InMemoryStorageStudyID = 1
@@ -70,20 +85,22 @@ var (
 // NewInMemoryStorage returns new memory storage.
 func NewInMemoryStorage() *InMemoryStorage {
 	return &InMemoryStorage{
-		direction:   StudyDirectionMinimize,
+		directions:  []StudyDirection{StudyDirectionMinimize},
 		trials:      make([]FrozenTrial, 0, 128),
 		userAttrs:   make(map[string]string, 8),
 		systemAttrs: make(map[string]string, 8),
+		heartbeats:  make(map[int]time.Time, 8),
 		studyName:   DefaultStudyNamePrefix + InMemoryStorageStudyUUID,
 	}
 }
 
 // InMemoryStorage stores data in memory of the Go process.
 type InMemoryStorage struct {
-	direction   StudyDirection
+	directions  []StudyDirection
 	trials      []FrozenTrial
 	userAttrs   map[string]string
 	systemAttrs map[string]string
+	heartbeats  map[int]time.Time
 	studyName   string
 
 	mu sync.RWMutex
@@ -105,10 +122,11 @@ func (s *InMemoryStorage) DeleteStudy(studyID int) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.direction = StudyDirectionMinimize
+	s.directions = []StudyDirection{StudyDirectionMinimize}
 	s.trials = make([]FrozenTrial, 0, 128)
 	s.userAttrs = make(map[string]string, 8)
 	s.systemAttrs = make(map[string]string, 8)
+	s.heartbeats = make(map[int]time.Time, 8)
 	s.studyName = DefaultStudyNamePrefix + InMemoryStorageStudyUUID
 	return nil
 }
@@ -121,7 +139,23 @@ func (s *InMemoryStorage) SetStudyDirection(studyID int, direction StudyDirectio
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.direction = direction
+	s.directions = []StudyDirection{direction}
+	return nil
+}
+
+// SetStudyDirections sets study directions of the multiple objectives.
+func (s *InMemoryStorage) SetStudyDirections(studyID int, directions []StudyDirection) error {
+	if !s.checkStudyID(studyID) {
+		return ErrInvalidStudyID
+	}
+	if len(directions) == 0 {
+		return errors.New("directions must contain one or more elements")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.directions = make([]StudyDirection, len(directions))
+	copy(s.directions, directions)
 	return nil
 }
 
@@ -143,6 +177,21 @@ func (s *InMemoryStorage) SetStudySystemAttr(studyID int, key string, value stri
 	return nil
 }
 
+// CreateStudySystemAttr stores the value for the system only if the key doesn't exist.
+func (s *InMemoryStorage) CreateStudySystemAttr(studyID int, key string, value string) (bool, error) {
+	if !s.checkStudyID(studyID) {
+		return false, ErrInvalidStudyID
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.systemAttrs[key]; ok {
+		return false, nil
+	}
+	s.systemAttrs[key] = value
+	return true, nil
+}
+
 // GetStudyIDFromName return the study id from study name.
 func (s *InMemoryStorage) GetStudyIDFromName(name string) (int, error) {
 	s.mu.RLock()
@@ -230,7 +279,7 @@ func (s *InMemoryStorage) GetAllStudySummaries() ([]StudySummary, error) {
 			continue
 		}
 
-		if s.direction == StudyDirectionMaximize {
+		if s.directions[0] == StudyDirectionMaximize {
 			if t.Value > bestTrial.Value {
 				bestTrial = t
 			}
@@ -254,7 +303,8 @@ func (s *InMemoryStorage) GetAllStudySummaries() ([]StudySummary, error) {
 		{
 			ID:            InMemoryStorageStudyID,
 			Name:          s.studyName,
-			Direction:     s.direction,
+			Direction:     s.directions[0],
+			Directions:    append([]StudyDirection{}, s.directions...),
 			BestTrial:     bestTrial,
 			UserAttrs:     ua,
 			SystemAttrs:   sa,
@@ -312,6 +362,7 @@ func (s *InMemoryStorage) CloneTrial(studyID int, baseTrial FrozenTrial) (int, e
 		Number:             number,
 		State:              baseTrial.State,
 		Value:              baseTrial.Value,
+		Values:             baseTrial.Values,
 		IntermediateValues: baseTrial.IntermediateValues,
 		DatetimeStart:      baseTrial.DatetimeStart,
 		DatetimeComplete:   baseTrial.DatetimeComplete,
@@ -337,6 +388,29 @@ func (s *InMemoryStorage) SetTrialValue(trialID int, value float64) error {
 		return ErrTrialCannotBeUpdated
 	}
 	trial.Value = value
+	trial.Values = []float64{value}
+	s.trials[trialID] = trial
+	return nil
+}
+
+// SetTrialValues sets the values of trial for multi-objective optimization.
+func (s *InMemoryStorage) SetTrialValues(trialID int, values []float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.validateTrialID(trialID) {
+		return ErrInvalidTrialID
+	}
+	if len(values) == 0 {
+		return errors.New("values must contain one or more elements")
+	}
+	trial := s.trials[trialID]
+	if trial.State.IsFinished() {
+		return ErrTrialCannotBeUpdated
+	}
+	trial.Value = values[0]
+	trial.Values = make([]float64, len(values))
+	copy(trial.Values, values)
 	s.trials[trialID] = trial
 	return nil
 }
@@ -413,6 +487,7 @@ func (s *InMemoryStorage) SetTrialState(trialID int, state TrialState) error {
 	trial.State = state
 	if trial.State.IsFinished() {
 		trial.DatetimeComplete = time.Now()
+		delete(s.heartbeats, trialID)
 	}
 	s.trials[trialID] = trial
 	return nil
@@ -532,14 +607,14 @@ func (s *InMemoryStorage) GetBestTrial(studyID int) (FrozenTrial, error) {
 			continue
 		}
 
-		if s.direction == StudyDirectionMaximize {
+		if s.directions[0] == StudyDirectionMaximize {
 			if !bestTrialIsSet {
 				bestTrial = s.trials[i]
 				bestTrialIsSet = true
 			} else if s.trials[i].Value > bestTrial.Value {
 				bestTrial = s.trials[i]
 			}
-		} else if s.direction == StudyDirectionMinimize {
+		} else if s.directions[0] == StudyDirectionMinimize {
 			if !bestTrialIsSet {
 				bestTrial = s.trials[i]
 				bestTrialIsSet = true
@@ -575,7 +650,20 @@ func (s *InMemoryStorage) GetStudyDirection(studyID int) (StudyDirection, error)
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	return s.direction, nil
+	return s.directions[0], nil
+}
+
+// GetStudyDirections returns study directions of the objectives.
+func (s *InMemoryStorage) GetStudyDirections(studyID int) ([]StudyDirection, error) {
+	if !s.checkStudyID(studyID) {
+		return nil, ErrInvalidStudyID
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	directions := make([]StudyDirection, len(s.directions))
+	copy(directions, s.directions)
+	return directions, nil
 }
 
 // GetTrial returns Trial.
@@ -589,6 +677,43 @@ func (s *InMemoryStorage) GetTrial(trialID int) (FrozenTrial, error) {
 	return s.trials[trialID], nil
 }
 
+// RecordHeartbeat records the heartbeat of the running trial.
+func (s *InMemoryStorage) RecordHeartbeat(trialID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.validateTrialID(trialID) {
+		return ErrInvalidTrialID
+	}
+	if s.trials[trialID].State.IsFinished() {
+		return ErrTrialCannotBeUpdated
+	}
+	s.heartbeats[trialID] = time.Now()
+	return nil
+}
+
+// GetStaleTrialIDs returns IDs of the running trials whose heartbeat is expired.
+func (s *InMemoryStorage) GetStaleTrialIDs(studyID int, gracePeriod time.Duration) ([]int, error) {
+	if !s.checkStudyID(studyID) {
+		return nil, ErrInvalidStudyID
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	staleTrialIDs := make([]int, 0, 8)
+	for trialID, heartbeat := range s.heartbeats {
+		if s.trials[trialID].State != TrialStateRunning {
+			continue
+		}
+		if now.Sub(heartbeat) > gracePeriod {
+			staleTrialIDs = append(staleTrialIDs, trialID)
+		}
+	}
+	sort.Ints(staleTrialIDs)
+	return staleTrialIDs, nil
+}
+
 func (s *InMemoryStorage) validateTrialID(trialID int) bool {
 	return trialID >= 0 && trialID < len(s.trials)
 }
@@ -2,6 +2,7 @@ package goptuna
 
 import (
 	"errors"
+	"sort"
 	"sync"
 	"time"
 )
@@ -16,17 +17,20 @@ var (
 )
 
 var _ Storage = &BlackHoleStorage{}
+var _ HeartbeatStorage = &BlackHoleStorage{}
+var _ StudySystemAttrCreator = &BlackHoleStorage{}
 
 // NewBlackHoleStorage returns BlackHoleStorage.
 func NewBlackHoleStorage(n int) *BlackHoleStorage {
 	return &BlackHoleStorage{
-		direction:   StudyDirectionMinimize,
+		directions:  []StudyDirection{StudyDirectionMinimize},
 		counter:     0,
 		nTrials:     n,
 		trials:      make([]FrozenTrial, n),
 		bestTrial:   FrozenTrial{},
 		userAttrs:   make(map[string]string, 8),
 		systemAttrs: make(map[string]string, 8),
+		heartbeats:  make(map[int]time.Time, 8),
 		studyName:   DefaultStudyNamePrefix + InMemoryStorageStudyUUID,
 	}
 }
@@ -40,13 +44,14 @@ func NewBlackHoleStorage(n int) *BlackHoleStorage {
 //
 // Currently, RandomSampler and CMA-ES sampler supports this storage.
 type BlackHoleStorage struct {
-	direction   StudyDirection
+	directions  []StudyDirection
 	counter     int
 	nTrials     int
 	trials      []FrozenTrial
 	bestTrial   FrozenTrial
 	userAttrs   map[string]string
 	systemAttrs map[string]string
+	heartbeats  map[int]time.Time
 	studyName   string
 	mu          sync.RWMutex
 }
@@ -67,10 +72,11 @@ func (s *BlackHoleStorage) DeleteStudy(studyID int) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.direction = StudyDirectionMinimize
+	s.directions = []StudyDirection{StudyDirectionMinimize}
 	s.trials = make([]FrozenTrial, 0, 128)
 	s.userAttrs = make(map[string]string, 8)
 	s.systemAttrs = make(map[string]string, 8)
+	s.heartbeats = make(map[int]time.Time, 8)
 	s.studyName = DefaultStudyNamePrefix + InMemoryStorageStudyUUID
 	return nil
 }
@@ -83,7 +89,23 @@ func (s *BlackHoleStorage) SetStudyDirection(studyID int, direction StudyDirecti
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.direction = direction
+	s.directions = []StudyDirection{direction}
+	return nil
+}
+
+// SetStudyDirections sets study directions of the multiple objectives.
+func (s *BlackHoleStorage) SetStudyDirections(studyID int, directions []StudyDirection) error {
+	if !s.checkStudyID(studyID) {
+		return ErrInvalidStudyID
+	}
+	if len(directions) == 0 {
+		return errors.New("directions must contain one or more elements")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.directions = make([]StudyDirection, len(directions))
+	copy(s.directions, directions)
 	return nil
 }
 
@@ -105,6 +127,21 @@ func (s *BlackHoleStorage) SetStudySystemAttr(studyID int, key string, value str
 	return nil
 }
 
+// CreateStudySystemAttr stores the value for the system only if the key doesn't exist.
+func (s *BlackHoleStorage) CreateStudySystemAttr(studyID int, key string, value string) (bool, error) {
+	if !s.checkStudyID(studyID) {
+		return false, ErrInvalidStudyID
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.systemAttrs[key]; ok {
+		return false, nil
+	}
+	s.systemAttrs[key] = value
+	return true, nil
+}
+
 // GetStudyIDFromName return the study id from study name.
 func (s *BlackHoleStorage) GetStudyIDFromName(name string) (int, error) {
 	s.mu.RLock()
@@ -147,7 +184,20 @@ func (s *BlackHoleStorage) GetStudyDirection(studyID int) (StudyDirection, error
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	return s.direction, nil
+	return s.directions[0], nil
+}
+
+// GetStudyDirections returns study directions of the objectives.
+func (s *BlackHoleStorage) GetStudyDirections(studyID int) ([]StudyDirection, error) {
+	if !s.checkStudyID(studyID) {
+		return nil, ErrInvalidStudyID
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	directions := make([]StudyDirection, len(s.directions))
+	copy(directions, s.directions)
+	return directions, nil
 }
 
 // GetStudyUserAttrs to restore the attributes for the user.
@@ -193,7 +243,8 @@ func (s *BlackHoleStorage) GetAllStudySummaries() ([]StudySummary, error) {
 		{
 			ID:            InMemoryStorageStudyID,
 			Name:          s.studyName,
-			Direction:     s.direction,
+			Direction:     s.directions[0],
+			Directions:    append([]StudyDirection{}, s.directions...),
 			BestTrial:     s.bestTrial,
 			UserAttrs:     ua,
 			SystemAttrs:   sa,
@@ -219,6 +270,7 @@ func (s *BlackHoleStorage) CreateNewTrial(studyID int) (int, error) {
 	if s.isPartiallyDeleted() && !s.trials[idx].State.IsFinished() {
 		err = ErrDeleteNonFinishedTrial
 	}
+	delete(s.heartbeats, trialID-s.nTrials)
 
 	s.trials[idx] = FrozenTrial{
 		ID:                 trialID,
@@ -260,6 +312,7 @@ func (s *BlackHoleStorage) CloneTrial(studyID int, baseTrial FrozenTrial) (int,
 		Number:             number,
 		State:              baseTrial.State,
 		Value:              baseTrial.Value,
+		Values:             baseTrial.Values,
 		IntermediateValues: baseTrial.IntermediateValues,
 		DatetimeStart:      baseTrial.DatetimeStart,
 		DatetimeComplete:   baseTrial.DatetimeComplete,
@@ -286,6 +339,30 @@ func (s *BlackHoleStorage) SetTrialValue(trialID int, value float64) error {
 		return ErrTrialCannotBeUpdated
 	}
 	trial.Value = value
+	trial.Values = []float64{value}
+	s.trials[idx] = trial
+	return nil
+}
+
+// SetTrialValues sets the values of trial for multi-objective optimization.
+func (s *BlackHoleStorage) SetTrialValues(trialID int, values []float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkTrialID(trialID); err != nil {
+		return err
+	}
+	if len(values) == 0 {
+		return errors.New("values must contain one or more elements")
+	}
+	idx := s.getTrialIndex(trialID)
+	trial := s.trials[idx]
+	if trial.State.IsFinished() {
+		return ErrTrialCannotBeUpdated
+	}
+	trial.Value = values[0]
+	trial.Values = make([]float64, len(values))
+	copy(trial.Values, values)
 	s.trials[idx] = trial
 	return nil
 }
@@ -366,6 +443,7 @@ func (s *BlackHoleStorage) SetTrialState(trialID int, state TrialState) error {
 	trial.State = state
 	if trial.State.IsFinished() {
 		trial.DatetimeComplete = time.Now()
+		delete(s.heartbeats, trialID)
 		s.updateBestTrial(trial)
 	}
 	s.trials[idx] = trial
@@ -519,6 +597,47 @@ func (s *BlackHoleStorage) GetTrialSystemAttrs(trialID int) (map[string]string,
 	return n, nil
 }
 
+// RecordHeartbeat records the heartbeat of the running trial.
+func (s *BlackHoleStorage) RecordHeartbeat(trialID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkTrialID(trialID); err != nil {
+		return err
+	}
+	if s.trials[s.getTrialIndex(trialID)].State.IsFinished() {
+		return ErrTrialCannotBeUpdated
+	}
+	s.heartbeats[trialID] = time.Now()
+	return nil
+}
+
+// GetStaleTrialIDs returns IDs of the running trials whose heartbeat is expired.
+// Please note that the trials which are already deleted are not returned.
+func (s *BlackHoleStorage) GetStaleTrialIDs(studyID int, gracePeriod time.Duration) ([]int, error) {
+	if !s.checkStudyID(studyID) {
+		return nil, ErrInvalidStudyID
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	staleTrialIDs := make([]int, 0, 8)
+	for trialID, heartbeat := range s.heartbeats {
+		if s.checkTrialID(trialID) != nil {
+			continue
+		}
+		if s.trials[s.getTrialIndex(trialID)].State != TrialStateRunning {
+			continue
+		}
+		if now.Sub(heartbeat) > gracePeriod {
+			staleTrialIDs = append(staleTrialIDs, trialID)
+		}
+	}
+	sort.Ints(staleTrialIDs)
+	return staleTrialIDs, nil
+}
+
 func (s *BlackHoleStorage) checkStudyID(studyID int) bool {
 	return studyID == InMemoryStorageStudyID
 }
@@ -560,11 +679,11 @@ func (s *BlackHoleStorage) updateBestTrial(trial FrozenTrial) {
 		return
 	}
 
-	if s.direction == StudyDirectionMaximize && trial.Value > s.bestTrial.Value {
+	if s.directions[0] == StudyDirectionMaximize && trial.Value > s.bestTrial.Value {
 		s.bestTrial = trial
 		return
 	}
-	if s.direction == StudyDirectionMinimize && trial.Value < s.bestTrial.Value {
+	if s.directions[0] == StudyDirectionMinimize && trial.Value < s.bestTrial.Value {
 		s.bestTrial = trial
 		return
 	}
@@ -297,3 +297,42 @@ func TestMemoryStorage_CloneTrial(t *testing.T) {
 		t.Errorf("DatetimeComplete should be %s, but got %s", trials[0].DatetimeComplete, baseTrial.DatetimeComplete)
 	}
 }
+
+func TestMemoryStorage_CreateStudySystemAttr(t *testing.T) {
+	storage := goptuna.NewInMemoryStorage()
+	studyID, err := storage.CreateNewStudy("")
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+
+	created, err := storage.CreateStudySystemAttr(studyID, "key", "value1")
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	if !created {
+		t.Errorf("the value should be stored")
+	}
+	created, err = storage.CreateStudySystemAttr(studyID, "key", "value2")
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	if created {
+		t.Errorf("the value should not be overwritten")
+	}
+	attrs, err := storage.GetStudySystemAttrs(studyID)
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	if attrs["key"] != "value1" {
+		t.Errorf("the value should be value1, but got %s", attrs["key"])
+	}
+
+	_, err = storage.CreateStudySystemAttr(studyID+1, "key", "value")
+	if err != goptuna.ErrInvalidStudyID {
+		t.Errorf("should be ErrInvalidStudyID, but got %v", err)
+	}
+}
@@ -2,20 +2,60 @@ package goptuna
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
 
 var errCreateNewTrial = errors.New("failed to create a new trial")
 
+var (
+	// ErrMultiObjectiveStudy represents the operation is not supported for the multi-objective study.
+	ErrMultiObjectiveStudy = errors.New("not supported for the multi-objective study")
+	// ErrTooManyFailedTrials represents the number of failed trials reaches OptimizeOptions.MaxFailedTrials.
+	ErrTooManyFailedTrials = errors.New("too many failed trials")
+	// ErrTrialTimeout represents the trial exceeds the deadline set by StudyOptionTrialTimeout.
+	ErrTrialTimeout = errors.New("trial is timed out")
+)
+
+const (
+	// fixedParamsSystemAttrKey is the key of the trial system attribute
+	// which holds the internal representations of the enqueued parameters.
+	fixedParamsSystemAttrKey = "fixed_params"
+	// fixedExternalParamsSystemAttrKey is the key of the trial system attribute
+	// which holds the external representations of the enqueued parameters.
+	fixedExternalParamsSystemAttrKey = "fixed_external_params"
+	// failReasonSystemAttrKey is the key of the trial system attribute
+	// which holds the reason why the trial is failed.
+	failReasonSystemAttrKey = "fail_reason"
+	// failedTrialSystemAttrKey is the key of the trial system attribute
+	// which holds the number of the failed trial retried by the trial.
+	failedTrialSystemAttrKey = "failed_trial"
+	// retryCountSystemAttrKey is the key of the trial system attribute
+	// which holds how many times the parameters are retried.
+	retryCountSystemAttrKey = "retry_count"
+	// enqueuedParamsSystemAttrKeyPrefix is the prefix of the key of the study system
+	// attribute which is created when the parameters are enqueued with
+	// EnqueueTrialOptionSkipIfExists. The key is followed by the hash of the parameters.
+	enqueuedParamsSystemAttrKeyPrefix = "enqueued_params:"
+)
+
 // FuncObjective is a type of objective function
 type FuncObjective func(trial Trial) (float64, error)
 
+// FuncMultiObjective is a type of objective function which returns multiple objective values.
+type FuncMultiObjective func(trial Trial) ([]float64, error)
+
+// FuncCallback is a type of function which is called after each trial is finished.
+type FuncCallback func(study *Study, trial FrozenTrial) error
+
 // StudyDirection represents the direction of the optimization
 type StudyDirection string
 
@@ -34,15 +74,74 @@ type Study struct {
 	RelativeSampler    RelativeSampler
 	Pruner             Pruner
 	definedSearchSpace map[string]interface{}
-	direction          StudyDirection
+	directions         []StudyDirection
 	logger             Logger
 	ignoreErr          bool
+	nJobs              int
+	trialTimeout       time.Duration
+	heartbeatInterval  time.Duration
+	maxRetry           int
 	trialNotification  chan FrozenTrial
+	callbacks          []FuncCallback
+	budgets            map[*trialBudget]struct{}
 	loadIfExists       bool
 	mu                 sync.RWMutex
+	distributions      map[string]recordedDistribution
+	distributionsMu    sync.Mutex
 	ctx                context.Context
 }
 
+type recordedDistribution struct {
+	distribution interface{}
+	trialNumber  int
+}
+
+// latestDistribution returns the latest distribution of the parameter in the study.
+// The trials are loaded only at the first lookup of each parameter name, and then
+// the distributions suggested by this process are cached. So incompatible
+// distributions suggested by the other processes after the lookup are not detected.
+func (s *Study) latestDistribution(name string) (recordedDistribution, bool, error) {
+	s.distributionsMu.Lock()
+	defer s.distributionsMu.Unlock()
+	if recorded, ok := s.distributions[name]; ok {
+		return recorded, true, nil
+	}
+
+	trials, err := s.GetTrials()
+	if err != nil && err != ErrTrialsPartiallyDeleted {
+		return recordedDistribution{}, false, err
+	}
+	for i := len(trials) - 1; i >= 0; i-- {
+		d, ok := trials[i].Distributions[name]
+		if !ok {
+			continue
+		}
+		recorded := recordedDistribution{
+			distribution: d,
+			trialNumber:  trials[i].Number,
+		}
+		s.setRecordedDistribution(name, recorded)
+		return recorded, true, nil
+	}
+	return recordedDistribution{}, false, nil
+}
+
+func (s *Study) recordDistribution(name string, distribution interface{}, trialNumber int) {
+	s.distributionsMu.Lock()
+	defer s.distributionsMu.Unlock()
+	s.setRecordedDistribution(name, recordedDistribution{
+		distribution: distribution,
+		trialNumber:  trialNumber,
+	})
+}
+
+func (s *Study) setRecordedDistribution(name string, recorded recordedDistribution) {
+	if s.distributions == nil {
+		s.distributions = make(map[string]recordedDistribution)
+	}
+	s.distributions[name] = recorded
+}
+
 // EnqueueTrial to enqueue a trial with given parameter values.
 // You can fix the next sampling parameters which will be evaluated in your
 // objective function.
@@ -58,7 +157,7 @@ func (s *Study) EnqueueTrial(internalParams map[string]float64) error {
 		return err
 	}
 
-	systemAttrs["fixed_params"] = string(paramJSONBytes)
+	systemAttrs[fixedParamsSystemAttrKey] = string(paramJSONBytes)
 	return s.appendTrial(
 		0,
 		nil,
@@ -72,6 +171,202 @@ func (s *Study) EnqueueTrial(internalParams map[string]float64) error {
 	)
 }
 
+// EnqueueTrialParams to enqueue a trial with given parameter values.
+// Unlike EnqueueTrial, this method accepts external representations like
+// the values returned by the Suggest methods, e.g. "adam" for SuggestCategorical.
+// Each value must be nil, bool, int, float64 or string.
+// The values are converted to internal representations when the parameters are suggested.
+//
+// This is an EXPERIMENTAL API and may be changed in the future.
+func (s *Study) EnqueueTrialParams(params map[string]interface{}, opts ...EnqueueTrialOption) error {
+	options := enqueueTrialOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	for name := range params {
+		if !isCategoricalChoice(params[name]) {
+			return fmt.Errorf("unsupported parameter value: %s=%#v", name, params[name])
+		}
+	}
+
+	paramJSONBytes, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	if options.skipIfExists {
+		exists, err := s.enqueuedParamsExist(params)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			exists, err = s.claimEnqueuedParams(string(paramJSONBytes))
+			if err != nil {
+				return err
+			}
+		}
+		if exists {
+			s.logger.Info("Trial with the same parameters is already enqueued or evaluated")
+			return nil
+		}
+	}
+	systemAttrs := make(map[string]string, 8)
+	systemAttrs[fixedExternalParamsSystemAttrKey] = string(paramJSONBytes)
+	return s.appendTrial(
+		0,
+		nil,
+		nil,
+		options.userAttrs,
+		systemAttrs,
+		nil,
+		TrialStateWaiting,
+		time.Now(),
+		time.Time{},
+	)
+}
+
+// claimEnqueuedParams creates the study system attribute of the parameters
+// to prevent the other workers from enqueueing the same parameters, then returns
+// true if the parameters are already claimed. If the storage doesn't implement
+// StudySystemAttrCreator, this always returns false and the workers might
+// enqueue the same parameters at the same time.
+func (s *Study) claimEnqueuedParams(paramsJSON string) (bool, error) {
+	storage, ok := s.Storage.(StudySystemAttrCreator)
+	if !ok {
+		s.logger.Warn("Enqueued parameters are not de-duplicated across workers",
+			"reason", "storage doesn't implement StudySystemAttrCreator")
+		return false, nil
+	}
+	key := fmt.Sprintf("%s%x", enqueuedParamsSystemAttrKeyPrefix, sha256.Sum256([]byte(paramsJSON)))
+	created, err := storage.CreateStudySystemAttr(s.ID, key, time.Now().Format(time.RFC3339))
+	return !created, err
+}
+
+// enqueuedParamsExist returns true if there is a trial which is enqueued with
+// or evaluated with the same values of the given parameters. The parameters
+// which are not given are not compared.
+func (s *Study) enqueuedParamsExist(params map[string]interface{}) (bool, error) {
+	trials, err := s.GetTrials()
+	if err != nil && err != ErrTrialsPartiallyDeleted {
+		return false, err
+	}
+	for i := range trials {
+		trialParams := trials[i].Params
+		if v, ok := trials[i].SystemAttrs[fixedExternalParamsSystemAttrKey]; ok {
+			trialParams, err = decodeJSONObject(v)
+			if err != nil {
+				return false, err
+			}
+		}
+		matched, err := containsParams(trialParams, params)
+		if err != nil {
+			return false, err
+		}
+		if matched {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// containsParams returns true if trialParams has all params with the same values.
+// The values are compared in JSON to ignore the difference of the numeric types.
+func containsParams(trialParams map[string]interface{}, params map[string]interface{}) (bool, error) {
+	for name := range params {
+		v, ok := trialParams[name]
+		if !ok {
+			return false, nil
+		}
+		a, err := json.Marshal(v)
+		if err != nil {
+			return false, err
+		}
+		b, err := json.Marshal(params[name])
+		if err != nil {
+			return false, err
+		}
+		if string(a) != string(b) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// decodeJSONObject decodes the JSON object with keeping the numbers as is.
+func decodeJSONObject(jsonString string) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(strings.NewReader(jsonString))
+	decoder.UseNumber()
+	var v map[string]interface{}
+	err := decoder.Decode(&v)
+	return v, err
+}
+
+// retryFailedTrial enqueues the parameters of the failed trial
+// if it is not retried more than StudyOptionRetryFailedTrials.
+// It returns true if the parameters are enqueued.
+func (s *Study) retryFailedTrial(trialID int) (bool, error) {
+	if s.maxRetry <= 0 {
+		return false, nil
+	}
+	trial, err := s.Storage.GetTrial(trialID)
+	if err != nil {
+		return false, err
+	}
+
+	retryCount := 0
+	if v, ok := trial.SystemAttrs[retryCountSystemAttrKey]; ok {
+		retryCount, err = strconv.Atoi(v)
+		if err != nil {
+			return false, err
+		}
+	}
+	if retryCount >= s.maxRetry {
+		return false, nil
+	}
+
+	// The fixed parameters which are not suggested yet should be inherited.
+	internalParams := make(map[string]float64, len(trial.InternalParams))
+	if fixedParamsJSON, ok := trial.SystemAttrs[fixedParamsSystemAttrKey]; ok {
+		err = json.Unmarshal([]byte(fixedParamsJSON), &internalParams)
+		if err != nil {
+			return false, err
+		}
+	}
+	for name := range trial.InternalParams {
+		internalParams[name] = trial.InternalParams[name]
+	}
+	paramJSONBytes, err := json.Marshal(internalParams)
+	if err != nil {
+		return false, err
+	}
+
+	systemAttrs := make(map[string]string, 8)
+	systemAttrs[fixedParamsSystemAttrKey] = string(paramJSONBytes)
+	if externalParamsJSON, ok := trial.SystemAttrs[fixedExternalParamsSystemAttrKey]; ok {
+		systemAttrs[fixedExternalParamsSystemAttrKey] = externalParamsJSON
+	}
+	systemAttrs[failedTrialSystemAttrKey] = strconv.Itoa(trial.Number)
+	systemAttrs[retryCountSystemAttrKey] = strconv.Itoa(retryCount + 1)
+	err = s.appendTrial(
+		0,
+		nil,
+		nil,
+		nil,
+		systemAttrs,
+		nil,
+		TrialStateWaiting,
+		time.Now(),
+		time.Time{},
+	)
+	if err != nil {
+		return false, err
+	}
+	s.logger.Info("Failed trial is enqueued to retry",
+		fmt.Sprintf("trialID=%d", trialID),
+		fmt.Sprintf("retryCount=%d", retryCount+1))
+	return true, nil
+}
+
 func (s *Study) popWaitingTrialID() (int, error) {
 	trials, err := s.Storage.GetAllTrials(s.ID)
 	if err == ErrTrialsPartiallyDeleted {
@@ -146,12 +441,17 @@ func (s *Study) appendTrial(
 	if intermediateValues == nil {
 		intermediateValues = make(map[int]float64)
 	}
+	var values []float64
+	if state == TrialStateComplete {
+		values = []float64{value}
+	}
 	trial := FrozenTrial{
 		ID:                 -1, // dummy value
 		StudyID:            s.ID,
 		Number:             -1, // dummy value
 		State:              state,
 		Value:              value,
+		Values:             values,
 		IntermediateValues: intermediateValues,
 		DatetimeStart:      datetimeStart,
 		DatetimeComplete:   datetimeComplete,
@@ -161,7 +461,15 @@ func (s *Study) appendTrial(
 		UserAttrs:          userAttrs,
 		SystemAttrs:        systemAttrs,
 	}
-	err := trial.validate()
+	return s.AddTrial(trial)
+}
+
+// AddTrial adds the trial to the study. This is useful to warm-start the optimization
+// from the past results, which are used by the samplers as prior observations.
+// Please use NewCompletedTrial to create a completed trial.
+// ID, StudyID and Number of the given trial are ignored.
+func (s *Study) AddTrial(trial FrozenTrial) error {
+	trial, err := s.prepareTrial(trial)
 	if err != nil {
 		return err
 	}
@@ -169,14 +477,76 @@ func (s *Study) appendTrial(
 	return err
 }
 
+// AddTrials adds the trials to the study. No trial is added if any of them is invalid.
+func (s *Study) AddTrials(trials []FrozenTrial) error {
+	prepared := make([]FrozenTrial, len(trials))
+	for i := range trials {
+		trial, err := s.prepareTrial(trials[i])
+		if err != nil {
+			return fmt.Errorf("trials[%d]: %w", i, err)
+		}
+		prepared[i] = trial
+	}
+	for i := range prepared {
+		if _, err := s.Storage.CloneTrial(s.ID, prepared[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Study) prepareTrial(trial FrozenTrial) (FrozenTrial, error) {
+	trial.ID = -1     // dummy value
+	trial.Number = -1 // dummy value
+	trial.StudyID = s.ID
+	trial.IntermediateValues = copyMap(trial.IntermediateValues)
+	trial.InternalParams = copyMap(trial.InternalParams)
+	trial.Params = copyMap(trial.Params)
+	trial.Distributions = copyMap(trial.Distributions)
+	trial.UserAttrs = copyMap(trial.UserAttrs)
+	trial.SystemAttrs = copyMap(trial.SystemAttrs)
+
+	if trial.State == TrialStateComplete {
+		if len(trial.Values) == 0 {
+			trial.Values = []float64{trial.Value}
+		}
+		if len(trial.Values) != len(s.directions) {
+			return trial, fmt.Errorf("the number of values must be %d, but got %d",
+				len(s.directions), len(trial.Values))
+		}
+		trial.Values = append([]float64(nil), trial.Values...)
+		trial.Value = trial.Values[0]
+	}
+	return trial, trial.validate()
+}
+
+func copyMap[K comparable, V any](m map[K]V) map[K]V {
+	copied := make(map[K]V, len(m))
+	for k := range m {
+		copied[k] = m[k]
+	}
+	return copied
+}
+
 // GetTrials returns all trials in this study.
 func (s *Study) GetTrials() ([]FrozenTrial, error) {
 	return s.Storage.GetAllTrials(s.ID)
 }
 
-// Direction returns the direction of objective function value
+// Direction returns the direction of objective function value.
+// If the study is multi-objective, this returns the direction of the first objective.
 func (s *Study) Direction() StudyDirection {
-	return s.direction
+	return s.directions[0]
+}
+
+// Directions returns the directions of objective function values.
+func (s *Study) Directions() []StudyDirection {
+	return s.directions
+}
+
+// IsMultiObjective returns true if the study has multiple objectives.
+func (s *Study) IsMultiObjective() bool {
+	return len(s.directions) > 1
 }
 
 // WithContext sets a context and it might cancel the execution of Optimize.
@@ -186,73 +556,110 @@ func (s *Study) WithContext(ctx context.Context) {
 	s.ctx = ctx
 }
 
-func (s *Study) runTrial(objective FuncObjective) (int, error) {
+// Ask creates a new trial, or pops a waiting trial which is enqueued by EnqueueTrial.
+// This method is a part of the ask-and-tell interface, which is useful when
+// the objective function is evaluated out of process.
+// Please call Tell or TellMulti to finish the returned trial.
+func (s *Study) Ask() (Trial, error) {
+	trial, _, err := s.ask(nil, false)
+	return trial, err
+}
+
+// Tell finishes the trial with the given state and objective value.
+// The value is used only if the state is TrialStateComplete.
+// If the state is TrialStatePruned, the last intermediate value is
+// registered as the value of the trial if present.
+func (s *Study) Tell(trialID int, state TrialState, value float64) error {
+	return s.TellMulti(trialID, state, []float64{value})
+}
+
+// TellMulti finishes the trial with the given state and objective values
+// for multi-objective optimization.
+func (s *Study) TellMulti(trialID int, state TrialState, values []float64) error {
+	if !state.IsFinished() {
+		return errors.New("state must be a finished state")
+	}
+	if state == TrialStateComplete && len(values) != len(s.directions) {
+		return fmt.Errorf("the number of objective values (%d) does not match"+
+			" the number of directions (%d)", len(values), len(s.directions))
+	}
+	_, err := s.tell(trialID, state, values)
+	return err
+}
+
+// ask creates a new trial or pops a waiting trial, then calls the relative sampler.
+// If heartbeat is true, the heartbeat of the trial is recorded from before
+// calling the relative sampler until the returned function is called.
+func (s *Study) ask(ctx context.Context, heartbeat bool) (Trial, func(), error) {
 	trialID, err := s.popWaitingTrialID()
 	if err != nil {
 		s.logger.Error("failed to pop a waiting trial",
 			fmt.Sprintf("err=%s", err))
-		return -1, err
+		return Trial{}, nil, err
 	}
 	if trialID == -1 {
 		trialID, err = s.Storage.CreateNewTrial(s.ID)
 		if err != nil {
 			s.logger.Error("failed to create a new trial",
 				fmt.Sprintf("err=%s", err))
-			return -1, errCreateNewTrial
+			return Trial{}, nil, errCreateNewTrial
 		}
 	}
 
+	stopHeartbeat := func() {}
+	if heartbeat {
+		stopHeartbeat = s.startHeartbeat(trialID)
+	}
 	trial := Trial{
 		Study: s,
 		ID:    trialID,
+		ctx:   ctx,
 	}
 	err = trial.CallRelativeSampler()
 	if err != nil {
+		stopHeartbeat()
 		s.logger.Error("failed to call relative sampler",
 			fmt.Sprintf("err=%s", err))
-		return -1, err
-	}
-
-	evaluation, objerr := objective(trial)
-	var state TrialState
-	if objerr == ErrTrialPruned {
-		state = TrialStatePruned
-		objerr = nil
-	} else if objerr != nil {
-		state = TrialStateFail
-	} else {
-		state = TrialStateComplete
+		return Trial{}, nil, err
 	}
+	return trial, stopHeartbeat, nil
+}
 
+// tell registers the result of the trial, then returns the registered values.
+func (s *Study) tell(trialID int, state TrialState, evaluations []float64) ([]float64, error) {
+	var err error
 	if state == TrialStateComplete {
 		// The trial.value of pruned trials are already set at trial.Report().
-		err = s.Storage.SetTrialValue(trialID, evaluation)
+		err = s.Storage.SetTrialValues(trialID, evaluations)
 		if err != nil {
 			s.logger.Error("Failed to set trial value",
 				fmt.Sprintf("trialID=%d", trialID),
 				fmt.Sprintf("state=%s", state.String()),
-				fmt.Sprintf("evaluation=%f", evaluation),
+				formatEvaluations(evaluations),
 				fmt.Sprintf("err=%s", err))
-			return trialID, err
+			return nil, err
 		}
-	} else if state == TrialStatePruned {
+	} else if state == TrialStatePruned && !s.IsMultiObjective() {
+		evaluations = nil
 		// Register the last intermediate value if present as the value of the trial.
 		trial, err := s.Storage.GetTrial(trialID)
 		if err != nil {
-			return -1, err
+			return nil, err
 		}
 		if lastStep, exists := trial.GetLatestStep(); exists {
-			evaluation = trial.IntermediateValues[lastStep]
-			err = s.Storage.SetTrialValue(trialID, evaluation)
+			evaluations = []float64{trial.IntermediateValues[lastStep]}
+			err = s.Storage.SetTrialValue(trialID, evaluations[0])
 			if err != nil {
 				s.logger.Error("Failed to set trial value",
 					fmt.Sprintf("trialID=%d", trialID),
 					fmt.Sprintf("state=%s", state.String()),
-					fmt.Sprintf("evaluation=%f", evaluation),
+					formatEvaluations(evaluations),
 					fmt.Sprintf("err=%s", err))
-				return -1, err
+				return nil, err
 			}
 		}
+	} else {
+		evaluations = nil
 	}
 
 	err = s.Storage.SetTrialState(trialID, state)
@@ -260,9 +667,88 @@ func (s *Study) runTrial(objective FuncObjective) (int, error) {
 		s.logger.Error("Failed to set trial state",
 			fmt.Sprintf("trialID=%d", trialID),
 			fmt.Sprintf("state=%s", state.String()),
-			fmt.Sprintf("evaluation=%f", evaluation),
+			formatEvaluations(evaluations),
 			fmt.Sprintf("err=%s", err))
-		return trialID, err
+		return nil, err
+	}
+	return evaluations, nil
+}
+
+// runTrial evaluates the objective function, then returns the ID and the state of the trial.
+// The returned error is the error of the objective function if the state is TrialStateFail.
+// Otherwise, the state is TrialStateRunning if the trial couldn't be finished by an error.
+func (s *Study) runTrial(ctx context.Context, objective FuncMultiObjective) (int, TrialState, error) {
+	var deadline time.Time
+	if s.trialTimeout > 0 {
+		if ctx == nil {
+			ctx = s.ctx
+		}
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		var cancel context.CancelFunc
+		deadline = time.Now().Add(s.trialTimeout)
+		ctx, cancel = context.WithDeadline(ctx, deadline)
+		defer cancel()
+	}
+
+	if err := s.FailStaleTrials(); err != nil {
+		s.logger.Error("Failed to fail the stale trials",
+			fmt.Sprintf("err=%s", err))
+	}
+
+	trial, stopHeartbeat, err := s.ask(ctx, true)
+	if err != nil {
+		return -1, TrialStateRunning, err
+	}
+	trialID := trial.ID
+
+	evaluations, objerr := objective(trial)
+	stopHeartbeat()
+	var state TrialState
+	timedOut := !deadline.IsZero() && !time.Now().Before(deadline)
+	if timedOut {
+		state = TrialStateFail
+		if objerr == nil || objerr == ErrTrialPruned {
+			objerr = ErrTrialTimeout
+		} else {
+			objerr = fmt.Errorf("%w: %s", ErrTrialTimeout, objerr)
+		}
+	} else if objerr == ErrTrialPruned {
+		state = TrialStatePruned
+		objerr = nil
+	} else if objerr != nil {
+		state = TrialStateFail
+	} else if len(evaluations) != len(s.directions) {
+		state = TrialStateFail
+		objerr = fmt.Errorf("the number of objective values (%d) does not match"+
+			" the number of directions (%d)", len(evaluations), len(s.directions))
+	} else {
+		state = TrialStateComplete
+	}
+
+	if state == TrialStateFail {
+		err = s.Storage.SetTrialSystemAttr(trialID, failReasonSystemAttrKey, objerr.Error())
+		if err != nil {
+			s.logger.Error("Failed to set the fail reason",
+				fmt.Sprintf("trialID=%d", trialID),
+				fmt.Sprintf("err=%s", err))
+			return trialID, TrialStateRunning, err
+		}
+	}
+
+	evaluations, err = s.tell(trialID, state, evaluations)
+	if err != nil {
+		return trialID, TrialStateRunning, err
+	}
+
+	var retried bool
+	if state == TrialStateFail {
+		if retried, err = s.retryFailedTrial(trialID); err != nil {
+			s.logger.Error("Failed to retry the trial",
+				fmt.Sprintf("trialID=%d", trialID),
+				fmt.Sprintf("err=%s", err))
+		}
 	}
 
 	if objerr != nil {
@@ -274,35 +760,152 @@ func (s *Study) runTrial(objective FuncObjective) (int, error) {
 		s.logger.Info("Trial finished",
 			fmt.Sprintf("trialID=%d", trialID),
 			fmt.Sprintf("state=%s", state.String()),
-			fmt.Sprintf("evaluation=%f", evaluation))
+			formatEvaluations(evaluations))
+	}
+	if timedOut || retried {
+		// The deadline fails only this trial, and the retried parameters
+		// should be evaluated, so the optimization continues.
+		return trialID, state, nil
+	}
+	return trialID, state, objerr
+}
+
+func formatEvaluations(evaluations []float64) string {
+	if len(evaluations) == 0 {
+		return "evaluation=none"
+	}
+	if len(evaluations) == 1 {
+		return fmt.Sprintf("evaluation=%f", evaluations[0])
 	}
-	return trialID, objerr
+	return fmt.Sprintf("evaluations=%v", evaluations)
+}
+
+// OptimizeOptions holds the conditions to stop OptimizeWithOptions.
+// The zero value of each field means that there is no limit.
+type OptimizeOptions struct {
+	// MaxTrials is the maximum number of trials to evaluate.
+	MaxTrials int
+	// Timeout is the wall-clock time budget of the optimization.
+	// New trials are not started after the timeout. The trials which are still
+	// running at the timeout are not interrupted, but the context returned by
+	// Trial.GetContext is canceled. They finish with the state determined by
+	// the result of the objective function, e.g. TrialStateFail if it returns an error.
+	Timeout time.Duration
+	// MaxFailedTrials is the number of failed trials to stop the optimization.
+	// If this is set, errors of the objective function do not stop the optimization
+	// until the number of failed trials reaches it, then ErrTooManyFailedTrials is returned.
+	// Only the trials finished with TrialStateFail are counted, and the other errors
+	// such as the errors of the storage are returned unless StudyOptionIgnoreError is set.
+	MaxFailedTrials int
 }
 
 // Optimize optimizes an objective function.
 func (s *Study) Optimize(objective FuncObjective, evaluateMax int) error {
-	evaluateCnt := 0
-	for {
-		if evaluateCnt >= evaluateMax {
-			break
+	if evaluateMax <= 0 {
+		return nil
+	}
+	return s.OptimizeWithOptions(objective, OptimizeOptions{MaxTrials: evaluateMax})
+}
+
+// OptimizeMulti optimizes an objective function which returns multiple values.
+// The number of returned values must be equal to the number of study directions.
+func (s *Study) OptimizeMulti(objective FuncMultiObjective, evaluateMax int) error {
+	if evaluateMax <= 0 {
+		return nil
+	}
+	return s.OptimizeMultiWithOptions(objective, OptimizeOptions{MaxTrials: evaluateMax})
+}
+
+// OptimizeWithOptions optimizes an objective function until one of the
+// conditions in OptimizeOptions is satisfied.
+func (s *Study) OptimizeWithOptions(objective FuncObjective, opts OptimizeOptions) error {
+	if s.IsMultiObjective() {
+		return ErrMultiObjectiveStudy
+	}
+	return s.OptimizeMultiWithOptions(func(trial Trial) ([]float64, error) {
+		evaluation, err := objective(trial)
+		return []float64{evaluation}, err
+	}, opts)
+}
+
+// OptimizeMultiWithOptions optimizes an objective function which returns
+// multiple values until one of the conditions in OptimizeOptions is satisfied.
+func (s *Study) OptimizeMultiWithOptions(objective FuncMultiObjective, opts OptimizeOptions) error {
+	budget := &trialBudget{
+		max:       opts.MaxTrials,
+		maxFailed: opts.MaxFailedTrials,
+	}
+	if opts.MaxTrials <= 0 {
+		budget.max = -1
+	}
+	if opts.Timeout > 0 {
+		parent := s.ctx
+		if parent == nil {
+			parent = context.Background()
 		}
+		var cancel context.CancelFunc
+		budget.ctx, cancel = context.WithTimeout(parent, opts.Timeout)
+		defer cancel()
+	}
+	return s.optimize(objective, budget)
+}
+
+func (s *Study) optimize(objective FuncMultiObjective, budget *trialBudget) error {
+	s.mu.Lock()
+	if s.budgets == nil {
+		s.budgets = make(map[*trialBudget]struct{}, 1)
+	}
+	s.budgets[budget] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.budgets, budget)
+		s.mu.Unlock()
+	}()
+
+	if s.nJobs <= 1 {
+		s.runWorker(objective, budget)
+		return budget.err
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < s.nJobs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.runWorker(objective, budget)
+		}()
+	}
+	wg.Wait()
+	return budget.err
+}
 
+// runWorker evaluates the objective function until the trial budget is exhausted,
+// the context is canceled or the budget is stopped by an error.
+func (s *Study) runWorker(objective FuncMultiObjective, budget *trialBudget) {
+	for budget.reserve() {
 		if s.ctx != nil {
 			select {
 			case <-s.ctx.Done():
 				err := s.ctx.Err()
 				s.logger.Debug("context is canceled", err)
-				return err
+				budget.stop(err)
+				return
 			default:
 				// do nothing
 			}
 		}
+		if s.isTimedOut(budget) {
+			s.logger.Info("Optimization is stopped by the timeout")
+			budget.stop(nil)
+			return
+		}
 		// Evaluate an objective function
-		trialID, err := s.runTrial(objective)
+		trialID, state, err := s.runTrial(budget.ctx, objective)
 		if err == errCreateNewTrial {
+			budget.release()
 			continue
 		}
-		evaluateCnt++
 
 		// Send trial notification
 		if s.trialNotification != nil {
@@ -312,21 +915,138 @@ func (s *Study) Optimize(objective FuncObjective, evaluateMax int) error {
 					fmt.Sprintf("trialID=%d", trialID),
 					fmt.Sprintf("err=%s", gerr))
 				if !s.ignoreErr {
-					return gerr
+					budget.stop(gerr)
+					return
 				}
 			}
 			s.trialNotification <- frozen
 		}
 
-		if !s.ignoreErr && err != nil {
+		// Call callbacks
+		if len(s.callbacks) > 0 && trialID >= 0 {
+			if cerr := s.callCallbacks(trialID); cerr != nil && !s.ignoreErr {
+				budget.stop(cerr)
+				return
+			}
+		}
+
+		if err != nil && s.isTimedOut(budget) {
+			// The trial is interrupted by the timeout.
+			s.logger.Info("Optimization is stopped by the timeout")
+			budget.stop(nil)
+			return
+		} else if state == TrialStateFail && budget.maxFailed > 0 {
+			// The error of the objective function doesn't stop the optimization
+			// until the number of failed trials reaches the limit.
+			if budget.fail() {
+				budget.stop(ErrTooManyFailedTrials)
+				return
+			}
+		} else if !s.ignoreErr && err != nil {
+			budget.stop(err)
+			return
+		}
+	}
+}
+
+func (s *Study) callCallbacks(trialID int) error {
+	frozen, err := s.Storage.GetTrial(trialID)
+	if err != nil {
+		s.logger.Error("Failed to get a trial for callbacks",
+			fmt.Sprintf("trialID=%d", trialID),
+			fmt.Sprintf("err=%s", err))
+		return err
+	}
+	for _, callback := range s.callbacks {
+		if err = callback(s, frozen); err != nil {
+			s.logger.Error("Callback returns error",
+				fmt.Sprintf("trialID=%d", trialID),
+				fmt.Sprintf("err=%s", err))
 			return err
 		}
 	}
 	return nil
 }
 
+// Stop stops the running Optimize methods of this study gracefully.
+// The trials which are already running are finished, but new trials are not started.
+// This method is goroutine safe, and can be called from objective functions or callbacks.
+// If no Optimize method is running, this method does nothing.
+func (s *Study) Stop() {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for budget := range s.budgets {
+		budget.stop(nil)
+	}
+}
+
+// isTimedOut returns true if the timeout of the budget is expired
+// while the study context is not canceled.
+func (s *Study) isTimedOut(budget *trialBudget) bool {
+	if budget.ctx == nil || budget.ctx.Err() == nil {
+		return false
+	}
+	return s.ctx == nil || s.ctx.Err() == nil
+}
+
+// trialBudget is the number of trials shared by the workers of Optimize.
+type trialBudget struct {
+	mu        sync.Mutex
+	max       int // negative value means unlimited.
+	reserved  int
+	maxFailed int // non-positive value means unlimited.
+	failed    int
+	stopped   bool
+	err       error
+
+	// ctx is passed to trials and canceled at the timeout.
+	// This is nil if there is no timeout.
+	ctx context.Context
+}
+
+// reserve returns true if a worker can evaluate one more trial.
+func (b *trialBudget) reserve() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.stopped || (b.max >= 0 && b.reserved >= b.max) {
+		return false
+	}
+	b.reserved++
+	return true
+}
+
+// fail counts a failed trial, then returns true if the number of
+// failed trials reaches the limit.
+func (b *trialBudget) fail() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failed++
+	return b.failed >= b.maxFailed
+}
+
+// release gives back the trial which is reserved but not evaluated.
+func (b *trialBudget) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.reserved--
+}
+
+// stop prevents all workers from evaluating new trials.
+// Only the first error is kept.
+func (b *trialBudget) stop(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.stopped = true
+	if b.err == nil {
+		b.err = err
+	}
+}
+
 // GetBestValue return the best objective value
 func (s *Study) GetBestValue() (float64, error) {
+	if s.IsMultiObjective() {
+		return 0.0, ErrMultiObjectiveStudy
+	}
 	trial, err := s.Storage.GetBestTrial(s.ID)
 	if err != nil {
 		return 0.0, err
@@ -336,6 +1056,9 @@ func (s *Study) GetBestValue() (float64, error) {
 
 // GetBestParams return parameters of the best trial
 func (s *Study) GetBestParams() (map[string]interface{}, error) {
+	if s.IsMultiObjective() {
+		return nil, ErrMultiObjectiveStudy
+	}
 	trial, err := s.Storage.GetBestTrial(s.ID)
 	if err != nil {
 		return nil, err
@@ -343,6 +1066,34 @@ func (s *Study) GetBestParams() (map[string]interface{}, error) {
 	return trial.Params, nil
 }
 
+// GetBestTrials returns the trials on the Pareto front, i.e., the completed
+// trials which are not dominated by any other completed trials.
+// For the single-objective study, this returns the trials which have the best value.
+func (s *Study) GetBestTrials() ([]FrozenTrial, error) {
+	trials, err := s.Storage.GetAllTrials(s.ID)
+	if err == ErrTrialsPartiallyDeleted {
+		s.logger.Warn("Some trials are not used to calculate the Pareto front.")
+		err = nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	completed := make([]FrozenTrial, 0, len(trials))
+	for i := range trials {
+		if trials[i].State != TrialStateComplete {
+			continue
+		}
+		if len(trials[i].Values) != len(s.directions) {
+			continue
+		}
+		completed = append(completed, trials[i])
+	}
+	if len(completed) == 0 {
+		return nil, ErrNoCompletedTrials
+	}
+	return paretoFront(completed, s.directions), nil
+}
+
 // SetUserAttr to store the value for the user.
 func (s *Study) SetUserAttr(key, value string) error {
 	return s.Storage.SetStudyUserAttr(s.ID, key, value)
@@ -381,13 +1132,14 @@ func CreateStudy(
 		Sampler:         sampler,
 		RelativeSampler: nil,
 		Pruner:          nil,
-		direction:       StudyDirectionMinimize,
+		directions:      []StudyDirection{StudyDirectionMinimize},
 		logger: &StdLogger{
 			Logger: log.New(os.Stdout, "", log.LstdFlags),
 			Level:  LoggerLevelDebug,
 			Color:  true,
 		},
 		ignoreErr: false,
+		nJobs:     1,
 	}
 
 	for _, opt := range opts {
@@ -395,6 +1147,9 @@ func CreateStudy(
 			return nil, err
 		}
 	}
+	if _, ok := study.Storage.(HeartbeatStorage); study.heartbeatInterval > 0 && !ok {
+		return nil, ErrHeartbeatNotSupported
+	}
 
 	if study.loadIfExists {
 		study, err := LoadStudy(name, opts...)
@@ -407,7 +1162,7 @@ func CreateStudy(
 	if err != nil {
 		return nil, err
 	}
-	err = study.Storage.SetStudyDirection(studyID, study.direction)
+	err = study.Storage.SetStudyDirections(studyID, study.directions)
 	if err != nil {
 		return nil, err
 	}
@@ -428,13 +1183,14 @@ func LoadStudy(
 		Sampler:         sampler,
 		RelativeSampler: nil,
 		Pruner:          nil,
-		direction:       "",
+		directions:      nil,
 		logger: &StdLogger{
 			Logger: log.New(os.Stdout, "", log.LstdFlags),
 			Level:  LoggerLevelDebug,
 			Color:  true,
 		},
 		ignoreErr: false,
+		nJobs:     1,
 	}
 
 	for _, opt := range opts {
@@ -442,17 +1198,20 @@ func LoadStudy(
 			return nil, err
 		}
 	}
+	if _, ok := study.Storage.(HeartbeatStorage); study.heartbeatInterval > 0 && !ok {
+		return nil, ErrHeartbeatNotSupported
+	}
 
 	studyID, err := study.Storage.GetStudyIDFromName(name)
 	if err != nil {
 		return nil, err
 	}
 	study.ID = studyID
-	direction, err := study.Storage.GetStudyDirection(studyID)
+	directions, err := study.Storage.GetStudyDirections(studyID)
 	if err != nil {
 		return nil, err
 	}
-	study.direction = direction
+	study.directions = directions
 	return study, nil
 }
 
@@ -467,3 +1226,104 @@ func DeleteStudy(
 	}
 	return storage.DeleteStudy(studyID)
 }
+
+// CopyStudy copies a study to the other storage. The direction, user and system
+// attributes of the study and all trials are copied. If toStudyName is empty,
+// the study is copied with the same name. This is useful to persist the study
+// which is created with InMemoryStorage, or to migrate it between databases.
+// The copied study is deleted if any error occurs while copying.
+func CopyStudy(
+	fromStorage Storage,
+	fromStudyName string,
+	toStorage Storage,
+	toStudyName string,
+) error {
+	if toStudyName == "" {
+		toStudyName = fromStudyName
+	}
+	fromStudyID, err := fromStorage.GetStudyIDFromName(fromStudyName)
+	if err != nil {
+		return err
+	}
+	directions, err := fromStorage.GetStudyDirections(fromStudyID)
+	if err != nil {
+		return err
+	}
+	userAttrs, err := fromStorage.GetStudyUserAttrs(fromStudyID)
+	if err != nil {
+		return err
+	}
+	systemAttrs, err := fromStorage.GetStudySystemAttrs(fromStudyID)
+	if err != nil {
+		return err
+	}
+	// Only the remaining trials are copied if some trials are already deleted.
+	trials, err := fromStorage.GetAllTrials(fromStudyID)
+	if err != nil && err != ErrTrialsPartiallyDeleted {
+		return err
+	}
+
+	toStudyID, err := toStorage.CreateNewStudy(toStudyName)
+	if err != nil {
+		return err
+	}
+	err = copyStudyTo(toStorage, toStudyID, directions, userAttrs, systemAttrs, trials)
+	if err != nil {
+		if deleteErr := toStorage.DeleteStudy(toStudyID); deleteErr != nil {
+			return fmt.Errorf("%w (failed to delete the copied study: %s)", err, deleteErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// storageInternalAttrKeys are the system attributes which are used by
+// the rdb storage to store the directions and the values. They must not be
+// copied because the destination storage stores them in its own way.
+var storageInternalAttrKeys = map[string]struct{}{
+	"multi_objective:study:directions": {},
+	"multi_objective:trial:values":     {},
+}
+
+func copyStudyTo(
+	storage Storage,
+	studyID int,
+	directions []StudyDirection,
+	userAttrs map[string]string,
+	systemAttrs map[string]string,
+	trials []FrozenTrial,
+) error {
+	if err := storage.SetStudyDirections(studyID, directions); err != nil {
+		return err
+	}
+	for key := range userAttrs {
+		if err := storage.SetStudyUserAttr(studyID, key, userAttrs[key]); err != nil {
+			return err
+		}
+	}
+	for key := range systemAttrs {
+		if _, ok := storageInternalAttrKeys[key]; ok {
+			continue
+		}
+		if err := storage.SetStudySystemAttr(studyID, key, systemAttrs[key]); err != nil {
+			return err
+		}
+	}
+	for _, trial := range trials {
+		trial.StudyID = studyID
+		trial.Values = append([]float64(nil), trial.Values...)
+		trial.IntermediateValues = copyMap(trial.IntermediateValues)
+		trial.InternalParams = copyMap(trial.InternalParams)
+		trial.Params = copyMap(trial.Params)
+		trial.Distributions = copyMap(trial.Distributions)
+		trial.UserAttrs = copyMap(trial.UserAttrs)
+		trial.SystemAttrs = copyMap(trial.SystemAttrs)
+		for key := range storageInternalAttrKeys {
+			delete(trial.SystemAttrs, key)
+		}
+		if _, err := storage.CloneTrial(studyID, trial); err != nil {
+			return err
+		}
+	}
+	return nil
+}
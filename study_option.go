@@ -1,12 +1,30 @@
 package goptuna
 
+import (
+	"errors"
+	"time"
+)
+
 // StudyOption to pass the custom option
 type StudyOption func(study *Study) error
 
 // StudyOptionDirection change the direction of optimize
 func StudyOptionDirection(direction StudyDirection) StudyOption {
 	return func(s *Study) error {
-		s.direction = direction
+		s.directions = []StudyDirection{direction}
+		return nil
+	}
+}
+
+// StudyOptionDirections sets the directions of multi-objective optimization.
+// Please use Study.OptimizeMulti with this option.
+func StudyOptionDirections(directions []StudyDirection) StudyOption {
+	return func(s *Study) error {
+		if len(directions) == 0 {
+			return errors.New("'directions' must contain one or more elements")
+		}
+		s.directions = make([]StudyDirection, len(directions))
+		copy(s.directions, directions)
 		return nil
 	}
 }
@@ -47,6 +65,37 @@ func StudyOptionRelativeSampler(sampler RelativeSampler) StudyOption {
 	}
 }
 
+// EnqueueTrialOption is a type of function to customize Study.EnqueueTrialParams.
+type EnqueueTrialOption func(options *enqueueTrialOptions)
+
+type enqueueTrialOptions struct {
+	skipIfExists bool
+	userAttrs    map[string]string
+}
+
+// EnqueueTrialOptionSkipIfExists skips to enqueue the parameters if there is
+// a trial which is already enqueued or evaluated with the same parameters.
+// Only the given parameters are compared, so that the trial evaluated with
+// the additional parameters is also regarded as the same one. The parameters
+// are de-duplicated across the workers if the storage implements
+// StudySystemAttrCreator like all storages in this module. Otherwise,
+// the workers might enqueue the same parameters at the same time.
+func EnqueueTrialOptionSkipIfExists(skip bool) EnqueueTrialOption {
+	return func(options *enqueueTrialOptions) {
+		options.skipIfExists = skip
+	}
+}
+
+// EnqueueTrialOptionUserAttrs sets the user attributes of the enqueued trial.
+func EnqueueTrialOptionUserAttrs(userAttrs map[string]string) EnqueueTrialOption {
+	return func(options *enqueueTrialOptions) {
+		options.userAttrs = make(map[string]string, len(userAttrs))
+		for k := range userAttrs {
+			options.userAttrs[k] = userAttrs[k]
+		}
+	}
+}
+
 // StudyOptionPruner sets the pruner object.
 func StudyOptionPruner(pruner Pruner) StudyOption {
 	return func(s *Study) error {
@@ -64,6 +113,67 @@ func StudyOptionIgnoreError(ignore bool) StudyOption {
 	}
 }
 
+// StudyOptionNJobs sets the number of goroutine workers which evaluate
+// the objective function in parallel at Optimize.
+// The number of trials passed to Optimize is shared by all workers, and all
+// workers stop at the first error unless StudyOptionIgnoreError is set.
+func StudyOptionNJobs(nJobs int) StudyOption {
+	return func(s *Study) error {
+		if nJobs < 1 {
+			return errors.New("'nJobs' must be larger than 0")
+		}
+		s.nJobs = nJobs
+		return nil
+	}
+}
+
+// StudyOptionTrialTimeout sets the deadline of each trial.
+// The context returned by Trial.GetContext is canceled at the deadline, and
+// the trial which is not finished until the deadline is recorded as TrialStateFail.
+// The reason is stored in "fail_reason" system attribute of the trial.
+// The timed-out trial doesn't stop the optimization even if StudyOptionIgnoreError is not set.
+func StudyOptionTrialTimeout(timeout time.Duration) StudyOption {
+	return func(s *Study) error {
+		if timeout <= 0 {
+			return errors.New("'timeout' must be larger than 0")
+		}
+		s.trialTimeout = timeout
+		return nil
+	}
+}
+
+// StudyOptionHeartbeatInterval enables to record the heartbeat of the running
+// trials at the given interval. The trials whose heartbeat is not recorded within
+// twice the interval are regarded as stale, e.g. the worker process is killed,
+// and they are changed to TrialStateFail by Study.FailStaleTrials.
+// The storage must implement HeartbeatStorage.
+func StudyOptionHeartbeatInterval(interval time.Duration) StudyOption {
+	return func(s *Study) error {
+		if interval <= 0 {
+			return errors.New("'interval' must be larger than 0")
+		}
+		s.heartbeatInterval = interval
+		return nil
+	}
+}
+
+// StudyOptionRetryFailedTrials enables to retry the failed trials up to maxRetry times.
+// The parameters of the failed trial are enqueued as a new TrialStateWaiting trial.
+// The new trial holds the number of the failed trial in "failed_trial" system attribute,
+// and how many times the parameters are retried in "retry_count" system attribute.
+// The failed trial which is retried doesn't stop the optimization even if
+// StudyOptionIgnoreError is not set, but the error of the objective function is
+// returned once the parameters are failed more than maxRetry times.
+func StudyOptionRetryFailedTrials(maxRetry int) StudyOption {
+	return func(s *Study) error {
+		if maxRetry < 0 {
+			return errors.New("'maxRetry' must be larger than or equal to 0")
+		}
+		s.maxRetry = maxRetry
+		return nil
+	}
+}
+
 // StudyOptionTrialNotifyChannel to subscribe the finished trials.
 func StudyOptionTrialNotifyChannel(notify chan FrozenTrial) StudyOption {
 	return func(s *Study) error {
@@ -72,6 +182,16 @@ func StudyOptionTrialNotifyChannel(notify chan FrozenTrial) StudyOption {
 	}
 }
 
+// StudyOptionCallbacks sets the functions which are called after each trial is finished.
+// Optimize stops when a callback returns an error unless StudyOptionIgnoreError is set.
+// Use Study.Stop inside of callbacks to stop the optimization gracefully.
+func StudyOptionCallbacks(callbacks ...FuncCallback) StudyOption {
+	return func(s *Study) error {
+		s.callbacks = append(s.callbacks, callbacks...)
+		return nil
+	}
+}
+
 // StudyOptionLoadIfExists to load the study if exists.
 func StudyOptionLoadIfExists(loadIfExists bool) StudyOption {
 	return func(s *Study) error {
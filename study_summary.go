@@ -7,6 +7,7 @@ type StudySummary struct {
 	ID            int               `json:"study_id"`
 	Name          string            `json:"study_name"`
 	Direction     StudyDirection    `json:"direction"`
+	Directions    []StudyDirection  `json:"directions"`
 	BestTrial     FrozenTrial       `json:"best_trial"`
 	UserAttrs     map[string]string `json:"user_attrs"`
 	SystemAttrs   map[string]string `json:"system_attrs"`
@@ -1,8 +1,12 @@
 package goptuna_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math"
+	"reflect"
+	"sync"
 	"testing"
 	"time"
 
@@ -130,6 +134,148 @@ func TestStudy_EnqueueTrial_WithUnfixedParameter(t *testing.T) {
 	}
 }
 
+func TestStudy_EnqueueTrialParams(t *testing.T) {
+	study, _ := goptuna.CreateStudy(
+		"example",
+		goptuna.StudyOptionLogger(nil),
+	)
+
+	objective := func(trial goptuna.Trial) (float64, error) {
+		x, _ := trial.SuggestFloat("x", -10, 10)
+		n, _ := trial.SuggestInt("n", 1, 8)
+		batchSize, _ := trial.SuggestCategoricalInt("batch_size", []int{16, 32, 64})
+		optimizer, _ := trial.SuggestCategorical("optimizer", []string{"adam", "sgd"})
+		if optimizer == "sgd" {
+			return x * float64(n*batchSize), nil
+		}
+		return x, nil
+	}
+
+	params := map[string]interface{}{"x": 2, "n": 4, "batch_size": 32, "optimizer": "sgd"}
+	err := study.EnqueueTrialParams(params,
+		goptuna.EnqueueTrialOptionSkipIfExists(true),
+		goptuna.EnqueueTrialOptionUserAttrs(map[string]string{"source": "manual"}))
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	// This is skipped because the same parameters are already enqueued.
+	err = study.EnqueueTrialParams(params, goptuna.EnqueueTrialOptionSkipIfExists(true))
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	if err = study.Optimize(objective, 1); err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	// This is skipped because the same parameters are already evaluated.
+	err = study.EnqueueTrialParams(params, goptuna.EnqueueTrialOptionSkipIfExists(true))
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+
+	trials, err := study.GetTrials()
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	if len(trials) != 1 {
+		t.Errorf("should be 1 trial, but got %d", len(trials))
+		return
+	}
+	expected := map[string]interface{}{"x": 2.0, "n": 4, "batch_size": 32, "optimizer": "sgd"}
+	if !reflect.DeepEqual(trials[0].Params, expected) {
+		t.Errorf("params should be %v, but got %v", expected, trials[0].Params)
+	}
+	if trials[0].UserAttrs["source"] != "manual" {
+		t.Errorf("user attrs should be set, but got %v", trials[0].UserAttrs)
+	}
+
+	err = study.EnqueueTrialParams(map[string]interface{}{"x": []int{1}})
+	if err == nil {
+		t.Errorf("should be error for the unsupported value")
+	}
+}
+
+func TestStudy_EnqueueTrialParams_SkipIfExistsWithPartialParams(t *testing.T) {
+	study, _ := goptuna.CreateStudy(
+		"example",
+		goptuna.StudyOptionLogger(nil),
+	)
+	err := study.Optimize(func(trial goptuna.Trial) (float64, error) {
+		x, _ := trial.SuggestFloat("x", -10, 10)
+		_, _ = trial.SuggestCategorical("optimizer", []string{"adam", "sgd"})
+		return x, nil
+	}, 1)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	trials, err := study.GetTrials()
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	x := trials[0].Params["x"]
+
+	tests := []struct {
+		name   string
+		params map[string]interface{}
+		want   int
+	}{
+		{name: "subset of the evaluated params", params: map[string]interface{}{"x": x}, want: 1},
+		{name: "different value", params: map[string]interface{}{"x": 11}, want: 2},
+		{name: "unknown param", params: map[string]interface{}{"x": x, "n": 1}, want: 3},
+	}
+	for _, tt := range tests {
+		err = study.EnqueueTrialParams(tt.params, goptuna.EnqueueTrialOptionSkipIfExists(true))
+		if err != nil {
+			t.Errorf("%s: err: %v != nil", tt.name, err)
+			return
+		}
+		trials, err = study.GetTrials()
+		if err != nil {
+			t.Errorf("%s: err: %v != nil", tt.name, err)
+			return
+		}
+		if len(trials) != tt.want {
+			t.Errorf("%s: should be %d trials, but got %d", tt.name, tt.want, len(trials))
+		}
+	}
+}
+
+func TestStudy_EnqueueTrialParams_SkipIfExistsConcurrently(t *testing.T) {
+	study, _ := goptuna.CreateStudy(
+		"example",
+		goptuna.StudyOptionLogger(nil),
+	)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := study.EnqueueTrialParams(map[string]interface{}{"x": 1, "optimizer": "adam"},
+				goptuna.EnqueueTrialOptionSkipIfExists(true))
+			if err != nil {
+				t.Errorf("err: %v != nil", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	trials, err := study.GetTrials()
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	if len(trials) != 1 {
+		t.Errorf("should be 1 trial, but got %d", len(trials))
+	}
+}
+
 func TestStudy_UserAttrs(t *testing.T) {
 	study, _ := goptuna.CreateStudy(
 		"example",
@@ -195,3 +341,914 @@ func TestStudy_AppendTrial(t *testing.T) {
 		return
 	}
 }
+
+func TestStudy_GetBestTrials(t *testing.T) {
+	study, err := goptuna.CreateStudy(
+		"example",
+		goptuna.StudyOptionDirections([]goptuna.StudyDirection{
+			goptuna.StudyDirectionMinimize,
+			goptuna.StudyDirectionMaximize,
+		}),
+		goptuna.StudyOptionLogger(nil),
+	)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+
+	values := [][]float64{
+		{1, 1}, // dominated by {0, 2}
+		{0, 2},
+		{2, 3},
+		{3, 3}, // dominated by {2, 3}
+	}
+	var i int
+	err = study.OptimizeMulti(func(trial goptuna.Trial) ([]float64, error) {
+		v := values[i]
+		i++
+		return v, nil
+	}, len(values))
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+
+	bestTrials, err := study.GetBestTrials()
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	if len(bestTrials) != 2 {
+		t.Errorf("should get two trials, but got %d", len(bestTrials))
+		return
+	}
+	for _, bt := range bestTrials {
+		if bt.Number != 1 && bt.Number != 2 {
+			t.Errorf("trial %d should be dominated: %v", bt.Number, bt.Values)
+		}
+	}
+
+	if _, err = study.GetBestValue(); err != goptuna.ErrMultiObjectiveStudy {
+		t.Errorf("err: %v != ErrMultiObjectiveStudy", err)
+	}
+	if err = study.Optimize(func(trial goptuna.Trial) (float64, error) {
+		return 0, nil
+	}, 1); err != goptuna.ErrMultiObjectiveStudy {
+		t.Errorf("err: %v != ErrMultiObjectiveStudy", err)
+	}
+}
+
+func TestStudy_OptimizeMulti_WithInvalidNumberOfValues(t *testing.T) {
+	study, err := goptuna.CreateStudy(
+		"example",
+		goptuna.StudyOptionDirections([]goptuna.StudyDirection{
+			goptuna.StudyDirectionMinimize,
+			goptuna.StudyDirectionMinimize,
+		}),
+		goptuna.StudyOptionLogger(nil),
+		goptuna.StudyOptionIgnoreError(true),
+	)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+
+	err = study.OptimizeMulti(func(trial goptuna.Trial) ([]float64, error) {
+		return []float64{1}, nil
+	}, 1)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+
+	trials, err := study.GetTrials()
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	if trials[0].State != goptuna.TrialStateFail {
+		t.Errorf("state should be fail, but got %s", trials[0].State)
+	}
+}
+
+func TestStudy_OptimizeWithNJobs(t *testing.T) {
+	study, err := goptuna.CreateStudy(
+		"example",
+		goptuna.StudyOptionNJobs(4),
+		goptuna.StudyOptionLogger(nil),
+	)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+
+	err = study.Optimize(func(trial goptuna.Trial) (float64, error) {
+		x1, _ := trial.SuggestFloat("x1", -10, 10)
+		return math.Pow(x1-2, 2), nil
+	}, 30)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+
+	trials, err := study.GetTrials()
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	if len(trials) != 30 {
+		t.Errorf("should evaluate 30 trials, but got %d", len(trials))
+	}
+}
+
+func TestStudy_OptimizeWithNJobs_StopOnError(t *testing.T) {
+	study, err := goptuna.CreateStudy(
+		"example",
+		goptuna.StudyOptionNJobs(4),
+		goptuna.StudyOptionLogger(nil),
+	)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+
+	objectiveErr := errors.New("objective error")
+	err = study.Optimize(func(trial goptuna.Trial) (float64, error) {
+		return 0, objectiveErr
+	}, 100)
+	if err != objectiveErr {
+		t.Errorf("err: %v != %v", err, objectiveErr)
+		return
+	}
+
+	trials, err := study.GetTrials()
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	if len(trials) > 4 {
+		t.Errorf("all workers should stop at the first error, but got %d trials", len(trials))
+	}
+}
+
+func TestStudy_OptimizeWithOptions_Timeout(t *testing.T) {
+	study, err := goptuna.CreateStudy(
+		"example",
+		goptuna.StudyOptionLogger(nil),
+	)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+
+	err = study.OptimizeWithOptions(func(trial goptuna.Trial) (float64, error) {
+		x1, _ := trial.SuggestFloat("x1", -10, 10)
+		select {
+		case <-trial.GetContext().Done():
+			return 0, trial.GetContext().Err()
+		case <-time.After(10 * time.Millisecond):
+			return x1, nil
+		}
+	}, goptuna.OptimizeOptions{
+		Timeout: 55 * time.Millisecond,
+	})
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+
+	trials, err := study.GetTrials()
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	if len(trials) == 0 {
+		t.Errorf("should evaluate one or more trials")
+	}
+	for i := range trials {
+		if !trials[i].State.IsFinished() {
+			t.Errorf("trial %d should be finished, but got %s", trials[i].Number, trials[i].State)
+		}
+	}
+}
+
+func TestStudy_OptimizeWithOptions_MaxFailedTrials(t *testing.T) {
+	study, err := goptuna.CreateStudy(
+		"example",
+		goptuna.StudyOptionLogger(nil),
+	)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+
+	var i int
+	err = study.OptimizeWithOptions(func(trial goptuna.Trial) (float64, error) {
+		i++
+		if i%2 == 0 {
+			return 0, errors.New("objective error")
+		}
+		return 1, nil
+	}, goptuna.OptimizeOptions{
+		MaxTrials:       100,
+		MaxFailedTrials: 3,
+	})
+	if err != goptuna.ErrTooManyFailedTrials {
+		t.Errorf("err: %v != ErrTooManyFailedTrials", err)
+		return
+	}
+
+	trials, err := study.GetTrials()
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	if len(trials) != 6 {
+		t.Errorf("should evaluate 6 trials, but got %d", len(trials))
+	}
+}
+
+type failingValuesStorage struct {
+	goptuna.Storage
+	err error
+}
+
+func (s failingValuesStorage) SetTrialValues(trialID int, values []float64) error {
+	return s.err
+}
+
+func TestStudy_OptimizeWithOptions_MaxFailedTrialsWithStorageError(t *testing.T) {
+	storageErr := errors.New("storage error")
+	study, err := goptuna.CreateStudy(
+		"example",
+		goptuna.StudyOptionStorage(failingValuesStorage{
+			Storage: goptuna.NewInMemoryStorage(),
+			err:     storageErr,
+		}),
+		goptuna.StudyOptionLogger(nil),
+	)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+
+	err = study.OptimizeWithOptions(func(trial goptuna.Trial) (float64, error) {
+		return 1, nil
+	}, goptuna.OptimizeOptions{
+		MaxTrials:       10,
+		MaxFailedTrials: 3,
+	})
+	if err != storageErr {
+		t.Errorf("err: %v != %v", err, storageErr)
+		return
+	}
+
+	trials, err := study.GetTrials()
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	if len(trials) != 1 {
+		t.Errorf("should stop at the first trial, but got %d trials", len(trials))
+	}
+}
+
+func TestStudy_Stop(t *testing.T) {
+	stopAfterThreeTrials := func(study *goptuna.Study, trial goptuna.FrozenTrial) error {
+		if trial.Number >= 2 {
+			study.Stop()
+		}
+		return nil
+	}
+	study, err := goptuna.CreateStudy(
+		"example",
+		goptuna.StudyOptionLogger(nil),
+		goptuna.StudyOptionCallbacks(stopAfterThreeTrials),
+	)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+
+	err = study.Optimize(func(trial goptuna.Trial) (float64, error) {
+		return trial.SuggestFloat("x1", -10, 10)
+	}, 100)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+
+	trials, err := study.GetTrials()
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	if len(trials) != 3 {
+		t.Errorf("should evaluate 3 trials, but got %d", len(trials))
+	}
+}
+
+func TestStudy_TrialTimeout(t *testing.T) {
+	study, err := goptuna.CreateStudy(
+		"example",
+		goptuna.StudyOptionLogger(nil),
+		goptuna.StudyOptionIgnoreError(true),
+		goptuna.StudyOptionTrialTimeout(20*time.Millisecond),
+	)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+
+	err = study.Optimize(func(trial goptuna.Trial) (float64, error) {
+		x1, _ := trial.SuggestFloat("x1", -10, 10)
+		if number, _ := trial.Number(); number%2 == 0 {
+			return x1, nil
+		}
+		select {
+		case <-trial.GetContext().Done():
+			return 0, trial.GetContext().Err()
+		case <-time.After(time.Second):
+			return x1, nil
+		}
+	}, 4)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+
+	trials, err := study.GetTrials()
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	if len(trials) != 4 {
+		t.Errorf("should evaluate 4 trials, but got %d", len(trials))
+		return
+	}
+	for _, trial := range trials {
+		if trial.Number%2 == 0 {
+			if trial.State != goptuna.TrialStateComplete {
+				t.Errorf("trial %d should be complete, but got %s", trial.Number, trial.State)
+			}
+			continue
+		}
+		if trial.State != goptuna.TrialStateFail {
+			t.Errorf("trial %d should be failed, but got %s", trial.Number, trial.State)
+		}
+		if _, ok := trial.SystemAttrs["fail_reason"]; !ok {
+			t.Errorf("trial %d should have the fail reason", trial.Number)
+		}
+	}
+}
+
+func TestStudy_TrialTimeout_ContinueOptimization(t *testing.T) {
+	study, err := goptuna.CreateStudy(
+		"example",
+		goptuna.StudyOptionLogger(nil),
+		goptuna.StudyOptionTrialTimeout(20*time.Millisecond),
+	)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+
+	err = study.Optimize(func(trial goptuna.Trial) (float64, error) {
+		if number, _ := trial.Number(); number == 0 {
+			<-trial.GetContext().Done()
+			return 0, trial.GetContext().Err()
+		}
+		return 1, nil
+	}, 3)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+
+	trials, err := study.GetTrials()
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	if len(trials) != 3 {
+		t.Errorf("should evaluate 3 trials, but got %d", len(trials))
+		return
+	}
+	if trials[0].State != goptuna.TrialStateFail {
+		t.Errorf("trial 0 should be failed, but got %s", trials[0].State)
+	}
+	if _, ok := trials[0].SystemAttrs["fail_reason"]; !ok {
+		t.Errorf("trial 0 should have the fail reason")
+	}
+	for _, trial := range trials[1:] {
+		if trial.State != goptuna.TrialStateComplete {
+			t.Errorf("trial %d should be complete, but got %s", trial.Number, trial.State)
+		}
+	}
+}
+
+func TestStudy_TrialTimeout_CancelStudyContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	study, err := goptuna.CreateStudy(
+		"example",
+		goptuna.StudyOptionLogger(nil),
+		goptuna.StudyOptionTrialTimeout(time.Minute),
+	)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	study.WithContext(ctx)
+
+	err = study.Optimize(func(trial goptuna.Trial) (float64, error) {
+		cancel()
+		<-trial.GetContext().Done()
+		return 0, trial.GetContext().Err()
+	}, 10)
+	if err != context.Canceled {
+		t.Errorf("err: %v != %v", err, context.Canceled)
+	}
+}
+
+func TestStudy_FailStaleTrials(t *testing.T) {
+	storage := goptuna.NewInMemoryStorage()
+	study, err := goptuna.CreateStudy(
+		"example",
+		goptuna.StudyOptionLogger(nil),
+		goptuna.StudyOptionStorage(storage),
+		goptuna.StudyOptionHeartbeatInterval(10*time.Millisecond),
+	)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+
+	// The worker of this trial is dead after recording the heartbeat.
+	trial, err := study.Ask()
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	err = storage.RecordHeartbeat(trial.ID)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	err = study.Optimize(func(trial goptuna.Trial) (float64, error) {
+		return trial.SuggestFloat("x1", -10, 10)
+	}, 1)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+
+	stale, err := storage.GetTrial(trial.ID)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	if stale.State != goptuna.TrialStateFail {
+		t.Errorf("stale trial should be failed, but got %s", stale.State)
+	}
+	if _, ok := stale.SystemAttrs["fail_reason"]; !ok {
+		t.Errorf("stale trial should have the fail reason")
+	}
+}
+
+// heartbeatCheckingSampler checks whether the heartbeat of the trial is
+// recorded before sampling.
+type heartbeatCheckingSampler struct {
+	storage  *goptuna.InMemoryStorage
+	recorded bool
+}
+
+func (s *heartbeatCheckingSampler) InferRelativeSearchSpace(
+	study *goptuna.Study,
+	trial goptuna.FrozenTrial,
+) (map[string]interface{}, error) {
+	time.Sleep(time.Millisecond)
+	trialIDs, err := s.storage.GetStaleTrialIDs(study.ID, 0)
+	for _, id := range trialIDs {
+		if id == trial.ID {
+			s.recorded = true
+		}
+	}
+	return nil, err
+}
+
+func (s *heartbeatCheckingSampler) SampleRelative(
+	*goptuna.Study,
+	goptuna.FrozenTrial,
+	map[string]interface{},
+) (map[string]float64, error) {
+	return nil, nil
+}
+
+func TestStudy_HeartbeatBeforeSampling(t *testing.T) {
+	storage := goptuna.NewInMemoryStorage()
+	sampler := &heartbeatCheckingSampler{storage: storage}
+	study, err := goptuna.CreateStudy(
+		"example",
+		goptuna.StudyOptionLogger(nil),
+		goptuna.StudyOptionStorage(storage),
+		goptuna.StudyOptionRelativeSampler(sampler),
+		goptuna.StudyOptionHeartbeatInterval(time.Second),
+	)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	err = study.Optimize(func(trial goptuna.Trial) (float64, error) {
+		return trial.SuggestFloat("x1", -10, 10)
+	}, 1)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	if !sampler.recorded {
+		t.Errorf("heartbeat should be recorded before sampling")
+	}
+}
+
+func TestStudy_HeartbeatNotSupported(t *testing.T) {
+	storage := struct{ goptuna.Storage }{goptuna.NewInMemoryStorage()}
+	_, err := goptuna.CreateStudy(
+		"example",
+		goptuna.StudyOptionLogger(nil),
+		goptuna.StudyOptionStorage(storage),
+		goptuna.StudyOptionHeartbeatInterval(time.Second),
+	)
+	if err != goptuna.ErrHeartbeatNotSupported {
+		t.Errorf("err: %v != %v", err, goptuna.ErrHeartbeatNotSupported)
+	}
+}
+
+func TestStudy_RetryFailedTrials(t *testing.T) {
+	study, err := goptuna.CreateStudy(
+		"example",
+		goptuna.StudyOptionLogger(nil),
+		goptuna.StudyOptionIgnoreError(true),
+		goptuna.StudyOptionRetryFailedTrials(1),
+	)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+
+	err = study.Optimize(func(trial goptuna.Trial) (float64, error) {
+		x1, _ := trial.SuggestFloat("x1", -10, 10)
+		if number, _ := trial.Number(); number < 2 {
+			return 0, errors.New("transient error")
+		}
+		return x1, nil
+	}, 3)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+
+	trials, err := study.GetTrials()
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	if len(trials) != 3 {
+		t.Errorf("should evaluate 3 trials, but got %d", len(trials))
+		return
+	}
+	if trials[1].Params["x1"] != trials[0].Params["x1"] {
+		t.Errorf("retried trial should have the same params: %v != %v",
+			trials[1].Params["x1"], trials[0].Params["x1"])
+	}
+	if trials[1].SystemAttrs["failed_trial"] != "0" {
+		t.Errorf("failed_trial should be 0, but got %s", trials[1].SystemAttrs["failed_trial"])
+	}
+	if trials[1].SystemAttrs["retry_count"] != "1" {
+		t.Errorf("retry_count should be 1, but got %s", trials[1].SystemAttrs["retry_count"])
+	}
+	if _, ok := trials[2].SystemAttrs["failed_trial"]; ok {
+		t.Errorf("trial exceeding the max retry should not be retried")
+	}
+}
+
+func TestStudy_RetryFailedTrials_WithoutIgnoreError(t *testing.T) {
+	study, err := goptuna.CreateStudy(
+		"example",
+		goptuna.StudyOptionLogger(nil),
+		goptuna.StudyOptionRetryFailedTrials(1),
+	)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+
+	transientErr := errors.New("transient error")
+	err = study.Optimize(func(trial goptuna.Trial) (float64, error) {
+		x1, _ := trial.SuggestFloat("x1", -10, 10)
+		if number, _ := trial.Number(); number == 0 {
+			return 0, transientErr
+		}
+		return x1, nil
+	}, 3)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	trials, err := study.GetTrials()
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	if len(trials) != 3 {
+		t.Errorf("should evaluate 3 trials, but got %d", len(trials))
+		return
+	}
+	if trials[1].State != goptuna.TrialStateComplete || trials[1].SystemAttrs["failed_trial"] != "0" {
+		t.Errorf("trial 1 should be the completed retry of trial 0, but got %s %v",
+			trials[1].State, trials[1].SystemAttrs)
+	}
+
+	// The error is returned once the parameters are failed more than maxRetry times.
+	err = study.Optimize(func(trial goptuna.Trial) (float64, error) {
+		return 0, transientErr
+	}, 10)
+	if err != transientErr {
+		t.Errorf("err: %v != %v", err, transientErr)
+		return
+	}
+	trials, err = study.GetTrials()
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	if len(trials) != 5 {
+		t.Errorf("should evaluate 5 trials, but got %d", len(trials))
+	}
+}
+
+func TestStudy_CallbackError(t *testing.T) {
+	callbackErr := errors.New("callback error")
+	study, err := goptuna.CreateStudy(
+		"example",
+		goptuna.StudyOptionLogger(nil),
+		goptuna.StudyOptionCallbacks(func(study *goptuna.Study, trial goptuna.FrozenTrial) error {
+			if trial.State != goptuna.TrialStateComplete {
+				t.Errorf("callback should be called after the trial is finished")
+			}
+			return callbackErr
+		}),
+	)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+
+	err = study.Optimize(func(trial goptuna.Trial) (float64, error) {
+		return trial.SuggestFloat("x1", -10, 10)
+	}, 100)
+	if err != callbackErr {
+		t.Errorf("err: %v != %v", err, callbackErr)
+	}
+}
+
+func ExampleStudy_Ask() {
+	study, _ := goptuna.CreateStudy(
+		"example",
+		goptuna.StudyOptionSampler(goptuna.NewRandomSampler(
+			goptuna.RandomSamplerOptionSeed(0),
+		)),
+		goptuna.StudyOptionLogger(nil),
+	)
+
+	for i := 0; i < 10; i++ {
+		trial, err := study.Ask()
+		if err != nil {
+			panic(err)
+		}
+		x1, _ := trial.SuggestFloat("x1", -10, 10)
+		x2, _ := trial.SuggestFloat("x2", -10, 10)
+
+		// Evaluate the objective function outside of goptuna,
+		// e.g. on a job queue, then tell the result.
+		value := math.Pow(x1-2, 2) + math.Pow(x2+5, 2)
+		if err = study.Tell(trial.ID, goptuna.TrialStateComplete, value); err != nil {
+			panic(err)
+		}
+	}
+
+	value, _ := study.GetBestValue()
+	fmt.Printf("Best trial: %.5f\n", value)
+	// Output:
+	// Best trial: 0.03833
+}
+
+func TestStudy_Tell_Pruned(t *testing.T) {
+	study, err := goptuna.CreateStudy(
+		"example",
+		goptuna.StudyOptionLogger(nil),
+	)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+
+	trial, err := study.Ask()
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	err = study.Storage.SetTrialIntermediateValue(trial.ID, 3, 0.5)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	err = study.Tell(trial.ID, goptuna.TrialStatePruned, 1.0)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+
+	frozen, err := study.Storage.GetTrial(trial.ID)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	if frozen.State != goptuna.TrialStatePruned {
+		t.Errorf("state should be pruned, but got %s", frozen.State)
+	}
+	if frozen.Value != 0.5 {
+		t.Errorf("value should be the last intermediate value, but got %f", frozen.Value)
+	}
+
+	if err = study.Tell(trial.ID, goptuna.TrialStateRunning, 1.0); err == nil {
+		t.Errorf("err should not be nil")
+	}
+}
+
+func TestStudy_AddTrials(t *testing.T) {
+	study, err := goptuna.CreateStudy("example", goptuna.StudyOptionLogger(nil))
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	distributions := map[string]interface{}{
+		"x": goptuna.FloatDistribution{Low: -10, High: 10},
+		"n": goptuna.IntDistribution{Low: 1, High: 8, Step: 1},
+	}
+	trials := make([]goptuna.FrozenTrial, 0, 3)
+	for i, x := range []float64{3, -1, 2} {
+		trial, err := goptuna.NewCompletedTrial(
+			map[string]interface{}{"x": x, "n": i + 1}, distributions, x*x)
+		if err != nil {
+			t.Errorf("err: %v != nil", err)
+			return
+		}
+		trials = append(trials, trial)
+	}
+
+	invalid := trials[0]
+	invalid.Values = []float64{1, 2}
+	err = study.AddTrials(append(trials, invalid))
+	if err == nil {
+		t.Errorf("should be error for the invalid trial")
+		return
+	}
+	if got, _ := study.GetTrials(); len(got) != 0 {
+		t.Errorf("no trial should be added, but got %d", len(got))
+		return
+	}
+
+	if err = study.AddTrials(trials); err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	got, err := study.GetTrials()
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	if len(got) != 3 {
+		t.Errorf("should be 3 trials, but got %d", len(got))
+		return
+	}
+	for i := range got {
+		if got[i].Number != i || got[i].State != goptuna.TrialStateComplete {
+			t.Errorf("unexpected trial: %#v", got[i])
+		}
+	}
+	best, err := study.GetBestParams()
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	if best["x"] != -1.0 || best["n"] != 2 {
+		t.Errorf("unexpected best params: %v", best)
+	}
+}
+
+func TestCopyStudy(t *testing.T) {
+	fromStorage := goptuna.NewInMemoryStorage()
+	study, err := goptuna.CreateStudy(
+		"example",
+		goptuna.StudyOptionStorage(fromStorage),
+		goptuna.StudyOptionDirection(goptuna.StudyDirectionMaximize),
+		goptuna.StudyOptionLogger(nil),
+	)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	_ = study.SetUserAttr("hello", "world")
+	_ = study.SetSystemAttr("foo", "bar")
+	err = study.Optimize(func(trial goptuna.Trial) (float64, error) {
+		x, _ := trial.SuggestFloat("x", -10, 10)
+		optimizer, _ := trial.SuggestCategorical("optimizer", []string{"adam", "sgd"})
+		_ = trial.SetUserAttr("optimizer", optimizer)
+		_ = trial.Report(0, x)
+		return x, nil
+	}, 5)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+
+	toStorage := goptuna.NewInMemoryStorage()
+	err = goptuna.CopyStudy(fromStorage, "example", toStorage, "")
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	copied, err := goptuna.LoadStudy("example",
+		goptuna.StudyOptionStorage(toStorage), goptuna.StudyOptionLogger(nil))
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	if copied.Direction() != goptuna.StudyDirectionMaximize {
+		t.Errorf("direction should be maximize, but got %s", copied.Direction())
+	}
+	if attrs, _ := copied.GetUserAttrs(); attrs["hello"] != "world" {
+		t.Errorf("user attrs should be copied, but got %v", attrs)
+	}
+	if attrs, _ := copied.GetSystemAttrs(); attrs["foo"] != "bar" {
+		t.Errorf("system attrs should be copied, but got %v", attrs)
+	}
+
+	expected, _ := study.GetTrials()
+	actual, err := copied.GetTrials()
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	for i := range actual {
+		// Study ID is changed by the copy.
+		actual[i].StudyID = expected[i].StudyID
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("trials should be copied: %#v != %#v", actual, expected)
+	}
+}
+
+func TestCopyStudy_TrialsPartiallyDeleted(t *testing.T) {
+	fromStorage := goptuna.NewBlackHoleStorage(2)
+	study, err := goptuna.CreateStudy(
+		"example",
+		goptuna.StudyOptionStorage(fromStorage),
+		goptuna.StudyOptionLogger(nil),
+	)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	err = study.Optimize(func(trial goptuna.Trial) (float64, error) {
+		return trial.SuggestFloat("x", -10, 10)
+	}, 5)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+
+	toStorage := goptuna.NewInMemoryStorage()
+	err = goptuna.CopyStudy(fromStorage, "example", toStorage, "")
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	copied, err := goptuna.LoadStudy("example",
+		goptuna.StudyOptionStorage(toStorage), goptuna.StudyOptionLogger(nil))
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	trials, err := copied.GetTrials()
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	if len(trials) != 2 {
+		t.Errorf("only the remaining trials should be copied, but got %d trials", len(trials))
+	}
+}
@@ -0,0 +1,152 @@
+package goptuna
+
+import (
+	"errors"
+	"fmt"
+)
+
+// suggester is implemented by the trials to suggest the internal representation
+// of the parameter from the given distribution.
+type suggester interface {
+	suggest(name string, distribution interface{}) (float64, error)
+}
+
+func suggestFloat(t suggester, name string, low, high float64, opts ...SuggestFloatOption) (float64, error) {
+	if low > high {
+		return 0, errors.New("'low' must be smaller than or equal to the 'high'")
+	}
+	if len(opts) == 0 {
+		return t.suggest(name, UniformDistribution{
+			High: high, Low: low,
+		})
+	}
+
+	d := FloatDistribution{
+		High: high, Low: low,
+	}
+	for _, opt := range opts {
+		opt(&d)
+	}
+	if d.Step < 0 {
+		return 0, errors.New("'step' must be larger than or equal to 0")
+	}
+	if d.Log && d.Low <= 0 {
+		return 0, errors.New("'low' must be larger than 0 for the log distribution")
+	}
+	ir, err := t.suggest(name, d)
+	if err != nil {
+		return 0, err
+	}
+	return d.ToExternalRepr(ir).(float64), nil
+}
+
+func suggestLogFloat(t suggester, name string, low, high float64) (float64, error) {
+	if low > high {
+		return 0, errors.New("'low' must be smaller than or equal to the 'high'")
+	}
+	return t.suggest(name, LogUniformDistribution{
+		High: high, Low: low,
+	})
+}
+
+func suggestDiscreteFloat(t suggester, name string, low, high, q float64) (float64, error) {
+	if low > high {
+		return 0, errors.New("'low' must be smaller than or equal to the 'high'")
+	}
+	d := DiscreteUniformDistribution{
+		High: high, Low: low, Q: q,
+	}
+	ir, err := t.suggest(name, d)
+	if err != nil {
+		return 0, err
+	}
+	return d.ToExternalRepr(ir).(float64), err
+}
+
+func suggestInt(t suggester, name string, low, high int, opts ...SuggestIntOption) (int, error) {
+	if low > high {
+		return 0, errors.New("'low' must be smaller than or equal to the 'high'")
+	}
+	if len(opts) == 0 {
+		d := IntUniformDistribution{
+			High: high, Low: low,
+		}
+		v, err := t.suggest(name, d)
+		return d.ToExternalRepr(v).(int), err
+	}
+
+	d := IntDistribution{
+		High: high, Low: low, Step: 1,
+	}
+	for _, opt := range opts {
+		opt(&d)
+	}
+	if d.Step <= 0 {
+		return 0, errors.New("'step' must be larger than 0")
+	}
+	if d.Log && d.Low < 1 {
+		return 0, errors.New("'low' must be larger than or equal to 1 for the log distribution")
+	}
+	v, err := t.suggest(name, d)
+	if err != nil {
+		return 0, err
+	}
+	return d.ToExternalRepr(v).(int), nil
+}
+
+func suggestStepInt(t suggester, name string, low, high, step int) (int, error) {
+	if low > high {
+		return 0, errors.New("'low' must be smaller than or equal to the 'high'")
+	}
+	if step <= 0 {
+		return 0, errors.New("'step' must be larger than 0")
+	}
+	d := StepIntUniformDistribution{
+		High: high, Low: low, Step: step,
+	}
+	v, err := t.suggest(name, d)
+	return d.ToExternalRepr(v).(int), err
+}
+
+func suggestCategoricalAny(t suggester, name string, choices []interface{}) (interface{}, error) {
+	if len(choices) == 0 {
+		return nil, errors.New("'choices' must contains one or more elements")
+	}
+	for i := range choices {
+		if !isCategoricalChoice(choices[i]) {
+			return nil, fmt.Errorf("unsupported categorical choice: %#v", choices[i])
+		}
+	}
+	d := CategoricalDistribution{
+		Choices: make([]interface{}, len(choices)),
+	}
+	copy(d.Choices, choices)
+	v, err := t.suggest(name, d)
+	if err != nil {
+		return nil, err
+	}
+	return d.Choices[int(v)], nil
+}
+
+func suggestCategorical[T string | int | float64 | bool](t suggester, name string, choices []T) (T, error) {
+	if len(choices) == 0 {
+		var zero T
+		return zero, errors.New("'choices' must contains one or more elements")
+	}
+	d := CategoricalDistribution{
+		Choices: make([]interface{}, len(choices)),
+	}
+	for i := range choices {
+		d.Choices[i] = choices[i]
+	}
+	v, err := t.suggest(name, d)
+	return choices[int(v)], err
+}
+
+func suggestDistribution(t suggester, name string, distribution Distribution) (interface{}, error) {
+	ir, err := t.suggest(name, distribution)
+	if err != nil {
+		return nil, err
+	}
+	return distribution.ToExternalRepr(ir), nil
+}
@@ -0,0 +1,35 @@
+package goptuna
+
+// SuggestFloatOption is a type of function to customize the distribution of SuggestFloat.
+type SuggestFloatOption func(distribution *FloatDistribution)
+
+// SuggestFloatOptionStep discretizes the parameter at intervals of the given step from 'low'.
+func SuggestFloatOptionStep(step float64) SuggestFloatOption {
+	return func(distribution *FloatDistribution) {
+		distribution.Step = step
+	}
+}
+
+// SuggestFloatOptionLog samples the parameter in the log domain.
+func SuggestFloatOptionLog(log bool) SuggestFloatOption {
+	return func(distribution *FloatDistribution) {
+		distribution.Log = log
+	}
+}
+
+// SuggestIntOption is a type of function to customize the distribution of SuggestInt.
+type SuggestIntOption func(distribution *IntDistribution)
+
+// SuggestIntOptionStep sets the spacing between the values from 'low'.
+func SuggestIntOptionStep(step int) SuggestIntOption {
+	return func(distribution *IntDistribution) {
+		distribution.Step = step
+	}
+}
+
+// SuggestIntOptionLog samples the parameter in the log domain.
+func SuggestIntOptionLog(log bool) SuggestIntOption {
+	return func(distribution *IntDistribution) {
+		distribution.Log = log
+	}
+}
@@ -0,0 +1,479 @@
+package tpe
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+
+	"github.com/c-bata/goptuna"
+)
+
+var (
+	_ goptuna.RelativeSampler             = &Sampler{}
+	_ goptuna.RelativeSearchSpaceInferrer = &Sampler{}
+)
+
+// sigma0Magnitude is the magnitude of the bandwidth of the multivariate kernels.
+const sigma0Magnitude = 0.2
+
+// InferRelativeSearchSpace returns the search space for SampleRelative. This is the
+// union of the group decomposed search spaces if SamplerOptionGroup(true) is given,
+// and the intersection search space otherwise.
+func (s *Sampler) InferRelativeSearchSpace(
+	study *goptuna.Study,
+	trial goptuna.FrozenTrial,
+) (map[string]interface{}, error) {
+	if !s.multivariate {
+		return nil, nil
+	}
+	if !s.group {
+		return goptuna.IntersectionSearchSpace(study)
+	}
+
+	groups, err := goptuna.GroupDecomposedSearchSpace(study)
+	if err != nil {
+		return nil, err
+	}
+	searchSpace := make(map[string]interface{})
+	for _, group := range groups {
+		for name, distribution := range group {
+			searchSpace[name] = distribution
+		}
+	}
+	return searchSpace, nil
+}
+
+// SampleRelative samples multiple dimensional parameters in a given search space
+// by the multivariate TPE. This returns nil unless SamplerOptionMultivariate(true)
+// is given, so that all parameters are sampled independently by Sample.
+// If SamplerOptionGroup(true) is given, the search space is decomposed into the
+// groups of the parameters which appear together, and each group is sampled jointly.
+func (s *Sampler) SampleRelative(
+	study *goptuna.Study,
+	trial goptuna.FrozenTrial,
+	searchSpace map[string]interface{},
+) (map[string]float64, error) {
+	if !s.multivariate || study.IsMultiObjective() {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.group {
+		return s.sampleRelative(study, searchSpace)
+	}
+
+	groups, err := goptuna.GroupDecomposedSearchSpace(study)
+	if err != nil {
+		return nil, err
+	}
+	params := make(map[string]float64, len(searchSpace))
+	for _, group := range groups {
+		subSpace := make(map[string]interface{}, len(group))
+		for name, distribution := range group {
+			if d, ok := searchSpace[name]; ok && reflect.DeepEqual(d, distribution) {
+				subSpace[name] = distribution
+			}
+		}
+		groupParams, err := s.sampleRelative(study, subSpace)
+		if err != nil {
+			return nil, err
+		}
+		for name, value := range groupParams {
+			params[name] = value
+		}
+	}
+	return params, nil
+}
+
+func (s *Sampler) sampleRelative(
+	study *goptuna.Study,
+	searchSpace map[string]interface{},
+) (map[string]float64, error) {
+	names := make([]string, 0, len(searchSpace))
+	for name := range searchSpace {
+		if single, _ := goptuna.DistributionIsSingle(searchSpace[name]); single {
+			continue
+		}
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+	sort.Strings(names)
+
+	space := make([]parzenParam, len(names))
+	for i, name := range names {
+		p, ok := newParzenParam(searchSpace[name])
+		if !ok {
+			return nil, goptuna.ErrUnsupportedSearchSpace
+		}
+		space[i] = p
+	}
+
+	observations, scores, err := getMultivariateObservationPairs(study, names, space, s.constantLiar)
+	if err != nil {
+		return nil, err
+	}
+	if countFinishedObservations(scores) < s.numberOfStartupTrials {
+		return nil, nil
+	}
+
+	belowIndices, aboveIndices := s.splitObservationIndices(scores)
+	below := make([][]float64, len(belowIndices))
+	for i, index := range belowIndices {
+		below[i] = observations[index]
+	}
+	above := make([][]float64, len(aboveIndices))
+	for i, index := range aboveIndices {
+		above[i] = observations[index]
+	}
+
+	estimatorBelow := newMultivariateParzenEstimator(below, space, s.params)
+	estimatorAbove := newMultivariateParzenEstimator(above, space, s.params)
+
+	var best []float64
+	bestScore := math.Inf(-1)
+	for i := 0; i < s.numberOfEICandidates; i++ {
+		x := estimatorBelow.sample(s.rng)
+		score := estimatorBelow.logPDF(x) - estimatorAbove.logPDF(x)
+		if best == nil || score > bestScore {
+			best = x
+			bestScore = score
+		}
+	}
+
+	params := make(map[string]float64, len(names))
+	for i, name := range names {
+		params[name] = space[i].toInternalRepr(best[i])
+	}
+	return params, nil
+}
+
+// getMultivariateObservationPairs returns the transformed parameters and the
+// scores of the trials which contain all the given parameters.
+func getMultivariateObservationPairs(
+	study *goptuna.Study,
+	names []string,
+	space []parzenParam,
+	constantLiar bool,
+) ([][]float64, [][2]float64, error) {
+	var sign float64 = 1
+	if study.Direction() == goptuna.StudyDirectionMaximize {
+		sign = -1
+	}
+
+	trials, err := study.GetTrials()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	observations := make([][]float64, 0, len(trials))
+	scores := make([][2]float64, 0, len(trials))
+	for _, trial := range trials {
+		if !isObservation(trial, constantLiar) {
+			continue
+		}
+		observation := make([]float64, len(names))
+		ok := true
+		for i, name := range names {
+			ir, found := trial.InternalParams[name]
+			if !found {
+				ok = false
+				break
+			}
+			observation[i] = space[i].transform(ir)
+		}
+		if !ok {
+			continue
+		}
+		observations = append(observations, observation)
+		scores = append(scores, observationScore(trial, sign))
+	}
+	return observations, scores, nil
+}
+
+// parzenParam holds the domain of a parameter for the multivariate Parzen estimator.
+// The numerical parameters are transformed into the log domain if needed.
+type parzenParam struct {
+	// low and high are the bounds of the kernels in the transformed domain.
+	// The bounds of the discrete parameters are extended by a half step.
+	low  float64
+	high float64
+	// origLow and origHigh are the bounds of the internal representations.
+	origLow  float64
+	origHigh float64
+	q        float64
+	log      bool
+	isInt    bool
+	nChoices int
+}
+
+func newParzenParam(distribution interface{}) (parzenParam, bool) {
+	switch d := distribution.(type) {
+	case goptuna.UniformDistribution:
+		return newNumericalParzenParam(d.Low, d.High, 0, false, false), true
+	case goptuna.LogUniformDistribution:
+		return newNumericalParzenParam(d.Low, d.High, 0, true, false), true
+	case goptuna.DiscreteUniformDistribution:
+		return newNumericalParzenParam(d.Low, d.High, d.Q, false, false), true
+	case goptuna.FloatDistribution:
+		if d.Log {
+			return newNumericalParzenParam(d.Low, d.High, 0, true, false), true
+		}
+		return newNumericalParzenParam(d.Low, d.High, d.Step, false, false), true
+	case goptuna.IntUniformDistribution:
+		return newNumericalParzenParam(float64(d.Low), float64(d.High), 1, false, true), true
+	case goptuna.StepIntUniformDistribution:
+		// The high endpoint is excluded from the range of StepIntUniformDistribution.
+		high := d.Low + (d.High-d.Low-1)/d.Step*d.Step
+		return newNumericalParzenParam(float64(d.Low), float64(high), float64(d.Step), false, true), true
+	case goptuna.IntDistribution:
+		if d.Log {
+			return newNumericalParzenParam(float64(d.Low), float64(d.High), 0, true, true), true
+		}
+		step := d.Step
+		if step <= 0 {
+			step = 1
+		}
+		return newNumericalParzenParam(float64(d.Low), float64(d.High), float64(step), false, true), true
+	case goptuna.CategoricalDistribution:
+		return parzenParam{nChoices: len(d.Choices)}, true
+	}
+	return parzenParam{}, false
+}
+
+func newNumericalParzenParam(low, high, q float64, log, isInt bool) parzenParam {
+	p := parzenParam{
+		origLow:  low,
+		origHigh: high,
+		q:        q,
+		log:      log,
+		isInt:    isInt,
+	}
+	if q > 0 {
+		low -= 0.5 * q
+		high += 0.5 * q
+	} else if isInt {
+		// The integer parameters in the log domain are rounded after sampling.
+		low -= 0.5
+		high += 0.5
+	}
+	if log {
+		low, high = math.Log(low), math.Log(high)
+	}
+	p.low, p.high = low, high
+	return p
+}
+
+func (p parzenParam) isCategorical() bool {
+	return p.nChoices > 0
+}
+
+func (p parzenParam) transform(ir float64) float64 {
+	if p.log {
+		return math.Log(ir)
+	}
+	return ir
+}
+
+func (p parzenParam) toInternalRepr(x float64) float64 {
+	if p.isCategorical() {
+		return x
+	}
+	if p.log {
+		x = math.Exp(x)
+	}
+	if p.q > 0 {
+		n := math.Floor((p.origHigh - p.origLow) / p.q)
+		k := math.Min(math.Max(math.Round((x-p.origLow)/p.q), 0), n)
+		return p.origLow + k*p.q
+	}
+	if p.isInt {
+		x = math.Round(x)
+	}
+	return math.Min(math.Max(x, p.origLow), p.origHigh)
+}
+
+// multivariateParzenEstimator is a mixture of the product kernels. Each kernel
+// is centered at an observation, and the last kernel is the prior if considered.
+type multivariateParzenEstimator struct {
+	space   []parzenParam
+	weights []float64
+	// mus and sigmas hold the kernels of each numerical parameter.
+	mus    [][]float64
+	sigmas [][]float64
+	// categoricalWeights holds the kernels of each categorical parameter.
+	categoricalWeights [][][]float64
+}
+
+func newMultivariateParzenEstimator(
+	observations [][]float64,
+	space []parzenParam,
+	params ParzenEstimatorParams,
+) *multivariateParzenEstimator {
+	n := len(observations)
+	considerPrior := params.ConsiderPrior || n == 0
+	nKernels := n
+	if considerPrior {
+		nKernels++
+	}
+
+	weights := make([]float64, 0, nKernels)
+	weights = append(weights, params.Weights(n)...)
+	if considerPrior {
+		weights = append(weights, params.PriorWeight)
+	}
+	var sum float64
+	for _, w := range weights {
+		sum += w
+	}
+	for i := range weights {
+		weights[i] /= sum
+	}
+
+	e := &multivariateParzenEstimator{
+		space:              space,
+		weights:            weights,
+		mus:                make([][]float64, len(space)),
+		sigmas:             make([][]float64, len(space)),
+		categoricalWeights: make([][][]float64, len(space)),
+	}
+
+	// The bandwidth follows the Scott's rule.
+	sigma0 := sigma0Magnitude * math.Pow(math.Max(float64(n), 1), -1.0/float64(len(space)+4))
+	for d, p := range space {
+		if p.isCategorical() {
+			kernels := make([][]float64, nKernels)
+			for k := range kernels {
+				kernels[k] = make([]float64, p.nChoices)
+				if k == n {
+					// The prior is the uniform distribution.
+					for c := range kernels[k] {
+						kernels[k][c] = 1 / float64(p.nChoices)
+					}
+					continue
+				}
+				for c := range kernels[k] {
+					kernels[k][c] = params.PriorWeight / float64(nKernels)
+				}
+				kernels[k][int(observations[k][d])]++
+				var total float64
+				for _, v := range kernels[k] {
+					total += v
+				}
+				for c := range kernels[k] {
+					kernels[k][c] /= total
+				}
+			}
+			e.categoricalWeights[d] = kernels
+			continue
+		}
+
+		mus := make([]float64, nKernels)
+		sigmas := make([]float64, nKernels)
+		width := p.high - p.low
+		minSigma := eps
+		if params.ConsiderMagicClip {
+			minSigma = width / math.Min(100.0, 1.0+float64(nKernels))
+		}
+		for k := 0; k < n; k++ {
+			mus[k] = observations[k][d]
+			sigmas[k] = math.Min(math.Max(sigma0*width, minSigma), width)
+		}
+		if considerPrior {
+			mus[n] = 0.5 * (p.low + p.high)
+			sigmas[n] = width
+		}
+		e.mus[d] = mus
+		e.sigmas[d] = sigmas
+	}
+	return e
+}
+
+// sample draws a point in the transformed domain.
+func (e *multivariateParzenEstimator) sample(rng *rand.Rand) []float64 {
+	k := sampleIndex(rng, e.weights)
+	x := make([]float64, len(e.space))
+	for d, p := range e.space {
+		if p.isCategorical() {
+			x[d] = float64(sampleIndex(rng, e.categoricalWeights[d][k]))
+			continue
+		}
+		x[d] = p.transform(p.toInternalRepr(
+			sampleTruncatedNormal(rng, e.mus[d][k], e.sigmas[d][k], p.low, p.high)))
+	}
+	return x
+}
+
+// logPDF returns the log density of the point in the transformed domain.
+func (e *multivariateParzenEstimator) logPDF(x []float64) float64 {
+	logs := make([]float64, len(e.weights))
+	for k, w := range e.weights {
+		logs[k] = math.Log(w)
+		for d, p := range e.space {
+			if p.isCategorical() {
+				logs[k] += math.Log(e.categoricalWeights[d][k][int(x[d])])
+				continue
+			}
+			logs[k] += truncatedNormalLogPDF(x[d], e.mus[d][k], e.sigmas[d][k], p)
+		}
+	}
+	return logSumExp(logs)
+}
+
+func truncatedNormalLogPDF(x, mu, sigma float64, p parzenParam) float64 {
+	sigma = math.Max(sigma, eps)
+	logZ := math.Log(math.Max(normCDF((p.high-mu)/sigma)-normCDF((p.low-mu)/sigma), eps))
+	if p.q > 0 {
+		upper := math.Min(x+0.5*p.q, p.high)
+		lower := math.Max(x-0.5*p.q, p.low)
+		mass := normCDF((upper-mu)/sigma) - normCDF((lower-mu)/sigma)
+		return math.Log(math.Max(mass, eps)) - logZ
+	}
+	z := (x - mu) / sigma
+	return -0.5*z*z - math.Log(math.Sqrt(2*math.Pi)*sigma) - logZ
+}
+
+func sampleTruncatedNormal(rng *rand.Rand, mu, sigma, low, high float64) float64 {
+	for i := 0; i < 100; i++ {
+		draw := rng.NormFloat64()*sigma + mu
+		if low <= draw && draw < high {
+			return draw
+		}
+	}
+	return math.Min(math.Max(mu, low), high)
+}
+
+func sampleIndex(rng *rand.Rand, weights []float64) int {
+	r := rng.Float64()
+	var cumsum float64
+	for i, w := range weights {
+		cumsum += w
+		if r < cumsum {
+			return i
+		}
+	}
+	return len(weights) - 1
+}
+
+func normCDF(z float64) float64 {
+	return 0.5 * math.Erfc(-z/math.Sqrt2)
+}
+
+func logSumExp(x []float64) float64 {
+	m := math.Inf(-1)
+	for _, v := range x {
+		m = math.Max(m, v)
+	}
+	if math.IsInf(m, -1) {
+		return m
+	}
+	var sum float64
+	for _, v := range x {
+		sum += math.Exp(v - m)
+	}
+	return m + math.Log(sum)
+}
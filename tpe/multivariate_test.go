@@ -0,0 +1,184 @@
+package tpe
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/c-bata/goptuna"
+)
+
+func TestMultivariateParzenEstimator_LogPDF(t *testing.T) {
+	space := []parzenParam{
+		{nChoices: 3},
+		newNumericalParzenParam(0, 10, 2, false, true),
+	}
+	observations := [][]float64{{0, 2}, {2, 8}, {1, 10}}
+	estimator := newMultivariateParzenEstimator(observations, space, ParzenEstimatorParams{
+		ConsiderPrior:     true,
+		PriorWeight:       1.0,
+		ConsiderMagicClip: true,
+		Weights:           DefaultWeights,
+	})
+
+	// The probabilities over all the discrete points should sum to 1.
+	var total float64
+	for c := 0; c < 3; c++ {
+		for v := 0; v <= 10; v += 2 {
+			total += math.Exp(estimator.logPDF([]float64{float64(c), float64(v)}))
+		}
+	}
+	if math.Abs(total-1) > 1e-6 {
+		t.Errorf("the sum of probabilities should be 1, but got %f", total)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		x := estimator.sample(rng)
+		if x[0] < 0 || x[0] > 2 || x[0] != math.Round(x[0]) {
+			t.Errorf("invalid categorical sample %f", x[0])
+		}
+		if x[1] < 0 || x[1] > 10 || int(x[1])%2 != 0 {
+			t.Errorf("invalid discrete sample %f", x[1])
+		}
+	}
+}
+
+func TestSampler_SampleRelative(t *testing.T) {
+	searchSpace := map[string]interface{}{
+		"x":    goptuna.UniformDistribution{Low: -10, High: 10},
+		"lr":   goptuna.LogUniformDistribution{Low: 1e-5, High: 1e-1},
+		"n":    goptuna.IntDistribution{Low: 1, High: 128, Log: true},
+		"step": goptuna.StepIntUniformDistribution{Low: 0, High: 10, Step: 5},
+		"kind": goptuna.CategoricalDistribution{Choices: []interface{}{"a", "b", "c"}},
+	}
+	for _, multivariate := range []bool{false, true} {
+		sampler := NewSampler(
+			SamplerOptionSeed(0),
+			SamplerOptionNumberOfStartupTrials(5),
+			SamplerOptionMultivariate(multivariate))
+		study, err := goptuna.CreateStudy("",
+			goptuna.StudyOptionSampler(sampler),
+			goptuna.StudyOptionRelativeSampler(sampler),
+			goptuna.StudyOptionDefineSearchSpace(searchSpace),
+			goptuna.StudyOptionLogger(nil))
+		if err != nil {
+			t.Errorf("should not be err, but got %s", err)
+			return
+		}
+		err = study.Optimize(func(trial goptuna.Trial) (float64, error) {
+			x, _ := trial.SuggestFloat("x", -10, 10)
+			lr, _ := trial.SuggestLogFloat("lr", 1e-5, 1e-1)
+			n, _ := trial.SuggestInt("n", 1, 128, goptuna.SuggestIntOptionLog(true))
+			step, _ := trial.SuggestStepInt("step", 0, 10, 5)
+			kind, _ := trial.SuggestCategorical("kind", []string{"a", "b", "c"})
+			value := x*x + math.Log10(lr) + float64(n) + float64(step)
+			if kind == "b" {
+				value--
+			}
+			return value, nil
+		}, 10)
+		if err != nil {
+			t.Errorf("should not be err, but got %s", err)
+			return
+		}
+
+		trialID, err := study.Storage.CreateNewTrial(study.ID)
+		if err != nil {
+			t.Errorf("should not be err, but got %s", err)
+			return
+		}
+		trial, err := study.Storage.GetTrial(trialID)
+		if err != nil {
+			t.Errorf("should not be err, but got %s", err)
+			return
+		}
+		params, err := sampler.SampleRelative(study, trial, searchSpace)
+		if err != nil {
+			t.Errorf("should not be err, but got %s", err)
+			return
+		}
+		if !multivariate {
+			if params != nil {
+				t.Errorf("should be nil, but got %v", params)
+			}
+			continue
+		}
+		if len(params) != len(searchSpace) {
+			t.Errorf("should sample %d params, but got %v", len(searchSpace), params)
+			return
+		}
+		for name, ir := range params {
+			if !searchSpace[name].(goptuna.Distribution).Contains(ir) {
+				t.Errorf("%s=%f is not contained in %#v", name, ir, searchSpace[name])
+			}
+		}
+	}
+}
+
+func TestSampler_SampleRelativeGroup(t *testing.T) {
+	sampler := NewSampler(
+		SamplerOptionSeed(0),
+		SamplerOptionNumberOfStartupTrials(3),
+		SamplerOptionMultivariate(true),
+		SamplerOptionGroup(true))
+	study, err := goptuna.CreateStudy("",
+		goptuna.StudyOptionSampler(sampler),
+		goptuna.StudyOptionRelativeSampler(sampler),
+		goptuna.StudyOptionLogger(nil))
+	if err != nil {
+		t.Errorf("should not be err, but got %s", err)
+		return
+	}
+	err = study.Optimize(func(trial goptuna.Trial) (float64, error) {
+		model, _ := trial.SuggestCategorical("model", []string{"svm", "rf"})
+		if model == "svm" {
+			c, _ := trial.SuggestLogFloat("svm_c", 1e-5, 1e5)
+			gamma, _ := trial.SuggestFloat("svm_gamma", 0, 1)
+			return math.Abs(math.Log10(c)) + gamma, nil
+		}
+		depth, _ := trial.SuggestInt("rf_max_depth", 2, 32)
+		return float64(depth), nil
+	}, 20)
+	if err != nil {
+		t.Errorf("should not be err, but got %s", err)
+		return
+	}
+
+	searchSpace, err := sampler.InferRelativeSearchSpace(study, goptuna.FrozenTrial{})
+	if err != nil {
+		t.Errorf("should not be err, but got %s", err)
+		return
+	}
+	for _, name := range []string{"model", "svm_c", "svm_gamma", "rf_max_depth"} {
+		if _, ok := searchSpace[name]; !ok {
+			t.Errorf("search space should contain %s, but got %v", name, searchSpace)
+			return
+		}
+	}
+
+	trialID, err := study.Storage.CreateNewTrial(study.ID)
+	if err != nil {
+		t.Errorf("should not be err, but got %s", err)
+		return
+	}
+	trial, err := study.Storage.GetTrial(trialID)
+	if err != nil {
+		t.Errorf("should not be err, but got %s", err)
+		return
+	}
+	params, err := sampler.SampleRelative(study, trial, searchSpace)
+	if err != nil {
+		t.Errorf("should not be err, but got %s", err)
+		return
+	}
+	if len(params) != len(searchSpace) {
+		t.Errorf("should sample all params in %v, but got %v", searchSpace, params)
+		return
+	}
+	for name, ir := range params {
+		if !searchSpace[name].(goptuna.Distribution).Contains(ir) {
+			t.Errorf("%s=%f is not contained in %#v", name, ir, searchSpace[name])
+		}
+	}
+}
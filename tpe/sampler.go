@@ -60,6 +60,9 @@ type Sampler struct {
 	params                ParzenEstimatorParams
 	rng                   *rand.Rand
 	randomSampler         *goptuna.RandomSampler
+	multivariate          bool
+	group                 bool
+	constantLiar          bool
 	mu                    sync.Mutex
 }
 
@@ -90,15 +93,19 @@ func (s *Sampler) splitObservationPairs(
 	configVals []float64,
 	lossVals [][2]float64,
 ) ([]float64, []float64) {
-	nbelow := s.gamma(len(configVals))
+	belowIndices, aboveIndices := s.splitObservationIndices(lossVals)
+	return choice(configVals, belowIndices), choice(configVals, aboveIndices)
+}
+
+// splitObservationIndices returns the indices of the observations in the "below"
+// and "above" groups. Each group keeps the original order of the observations.
+func (s *Sampler) splitObservationIndices(lossVals [][2]float64) ([]int, []int) {
+	nbelow := s.gamma(len(lossVals))
 	lossAscending := argSort2d(lossVals)
 
 	sort.Ints(lossAscending[:nbelow])
-	below := choice(configVals, lossAscending[:nbelow])
-
 	sort.Ints(lossAscending[nbelow:])
-	above := choice(configVals, lossAscending[nbelow:])
-	return below, above
+	return lossAscending[:nbelow], lossAscending[nbelow:]
 }
 
 func (s *Sampler) sampleFromGMM(parzenEstimator *ParzenEstimator, low, high float64, size int, q float64, isLog bool) []float64 {
@@ -410,24 +417,51 @@ func (s *Sampler) sampleStepInt(distribution goptuna.StepIntUniformDistribution,
 }
 
 func (s *Sampler) sampleDiscreteUniform(distribution goptuna.DiscreteUniformDistribution, below, above []float64) float64 {
-	q := distribution.Q
-	r := distribution.High - distribution.Low
+	return s.sampleDiscrete(distribution.Low, distribution.High, distribution.Q, below, above)
+}
+
+func (s *Sampler) sampleDiscrete(low, high, q float64, below, above []float64) float64 {
+	r := high - low
 
 	// [low, high] is shifted to [0, r] to align sampled values at regular intervals.
 	// See https://github.com/optuna/optuna/pull/917#issuecomment-586114630 for details.
-	low := 0 - 0.5*q
-	high := r + 0.5*q
+	shiftedLow := 0 - 0.5*q
+	shiftedHigh := r + 0.5*q
 
 	// Shift below and above to [0, r]
 	for i := range below {
-		below[i] -= distribution.Low
+		below[i] -= low
 	}
 	for i := range above {
-		above[i] -= distribution.Low
+		above[i] -= low
 	}
 
-	best := s.sampleNumerical(low, high, below, above, q, false) + distribution.Low
-	return math.Min(math.Max(best, distribution.Low), distribution.High)
+	best := s.sampleNumerical(shiftedLow, shiftedHigh, below, above, q, false) + low
+	return math.Min(math.Max(best, low), high)
+}
+
+func (s *Sampler) sampleFloat(distribution goptuna.FloatDistribution, below, above []float64) float64 {
+	if distribution.Step > 0 && !distribution.Log {
+		best := s.sampleDiscrete(distribution.Low, distribution.High, distribution.Step, below, above)
+		return distribution.ToExternalRepr(best).(float64)
+	}
+	best := s.sampleNumerical(distribution.Low, distribution.High, below, above, 0, distribution.Log)
+	return distribution.ToExternalRepr(best).(float64)
+}
+
+func (s *Sampler) sampleIntDistribution(distribution goptuna.IntDistribution, below, above []float64) float64 {
+	low := float64(distribution.Low)
+	high := float64(distribution.High)
+	if !distribution.Log {
+		step := float64(distribution.Step)
+		if step <= 0 {
+			step = 1
+		}
+		best := s.sampleDiscrete(low, high, step, below, above)
+		return float64(distribution.ToExternalRepr(best).(int))
+	}
+	best := s.sampleNumerical(low-0.5, high+0.5, below, above, 0, true)
+	return float64(distribution.ToExternalRepr(best).(int))
 }
 
 func (s *Sampler) sampleCategorical(distribution goptuna.CategoricalDistribution, below, above []float64) float64 {
@@ -499,11 +533,11 @@ func (s *Sampler) Sample(
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	values, scores, err := getObservationPairs(study, paramName)
+	values, scores, err := getObservationPairs(study, paramName, s.constantLiar)
 	if err != nil {
 		return 0, err
 	}
-	n := len(values)
+	n := countFinishedObservations(scores)
 
 	if n < s.numberOfStartupTrials {
 		return s.randomSampler.Sample(study, trial, paramName, paramDistribution)
@@ -524,11 +558,15 @@ func (s *Sampler) Sample(
 		return s.sampleDiscreteUniform(d, belowParamValues, aboveParamValues), nil
 	case goptuna.CategoricalDistribution:
 		return s.sampleCategorical(d, belowParamValues, aboveParamValues), nil
+	case goptuna.FloatDistribution:
+		return s.sampleFloat(d, belowParamValues, aboveParamValues), nil
+	case goptuna.IntDistribution:
+		return s.sampleIntDistribution(d, belowParamValues, aboveParamValues), nil
 	}
 	return 0, goptuna.ErrUnknownDistribution
 }
 
-func getObservationPairs(study *goptuna.Study, paramName string) ([]float64, [][2]float64, error) {
+func getObservationPairs(study *goptuna.Study, paramName string, constantLiar bool) ([]float64, [][2]float64, error) {
 	var sign float64 = 1
 	if study.Direction() == goptuna.StudyDirectionMaximize {
 		sign = -1
@@ -542,39 +580,68 @@ func getObservationPairs(study *goptuna.Study, paramName string) ([]float64, [][
 	values := make([]float64, 0, len(trials))
 	scores := make([][2]float64, 0, len(trials))
 	for _, trial := range trials {
-		if trial.State != goptuna.TrialStateComplete && trial.State != goptuna.TrialStatePruned {
+		if !isObservation(trial, constantLiar) {
 			continue
 		}
 		ir, ok := trial.InternalParams[paramName]
 		if !ok {
 			continue
 		}
+		values = append(values, ir)
+		scores = append(scores, observationScore(trial, sign))
+	}
+	return values, scores, nil
+}
 
-		var paramValue, score0, score1 float64
-		paramValue = ir
-		if trial.State == goptuna.TrialStateComplete {
-			score0 = math.Inf(-1)
-			score1 = sign * trial.Value
-		} else {
-			if len(trial.IntermediateValues) > 0 {
-				var step int
-				var intermediateValue float64
-
-				for key := range trial.IntermediateValues {
-					if key > step {
-						step = key
-						intermediateValue = trial.IntermediateValues[key]
-					}
-				}
-				score0 = float64(-step)
-				score1 = sign * intermediateValue
-			} else {
-				score0 = math.Inf(1)
-				score1 = 0.0
+// isObservation returns whether the trial is used as an observation.
+// The running trials are also used if the constant liar is enabled.
+func isObservation(trial goptuna.FrozenTrial, constantLiar bool) bool {
+	switch trial.State {
+	case goptuna.TrialStateComplete, goptuna.TrialStatePruned:
+		return true
+	case goptuna.TrialStateRunning:
+		return constantLiar
+	}
+	return false
+}
+
+// runningScore is the pessimistic score of the running trials,
+// which puts them at the end of the "above" group.
+var runningScore = [2]float64{math.Inf(1), math.Inf(1)}
+
+// observationScore returns the score of the trial to sort the observations.
+// The completed trials come first in ascending order of the values, followed by
+// the pruned trials in descending order of the last steps and the running trials.
+func observationScore(trial goptuna.FrozenTrial, sign float64) [2]float64 {
+	if trial.State == goptuna.TrialStateRunning {
+		return runningScore
+	}
+	if trial.State == goptuna.TrialStateComplete {
+		return [2]float64{math.Inf(-1), sign * trial.Value}
+	}
+	if len(trial.IntermediateValues) > 0 {
+		var step int
+		var intermediateValue float64
+
+		for key := range trial.IntermediateValues {
+			if key > step {
+				step = key
+				intermediateValue = trial.IntermediateValues[key]
 			}
 		}
-		values = append(values, paramValue)
-		scores = append(scores, [2]float64{score0, score1})
+		return [2]float64{float64(-step), sign * intermediateValue}
 	}
-	return values, scores, nil
+	return [2]float64{math.Inf(1), 0.0}
+}
+
+// countFinishedObservations returns the number of the observations except the
+// running trials, which is compared with the number of startup trials.
+func countFinishedObservations(scores [][2]float64) int {
+	n := 0
+	for i := range scores {
+		if scores[i] != runningScore {
+			n++
+		}
+	}
+	return n
 }
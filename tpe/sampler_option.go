@@ -23,7 +23,8 @@ func SamplerOptionSeed(seed int64) SamplerOption {
 // SamplerOptionConsiderPrior enhance the stability of Parzen estimator
 // by imposing a Gaussian prior when True. The prior is only effective
 // if the sampling distribution is either `UniformDistribution`,
-// `DiscreteUniformDistribution`, `LogUniformDistribution`, or `IntUniformDistribution`.
+// `DiscreteUniformDistribution`, `LogUniformDistribution`, `IntUniformDistribution`,
+// `FloatDistribution` or `IntDistribution`.
 func SamplerOptionConsiderPrior(considerPrior bool) SamplerOption {
 	return func(sampler *Sampler) {
 		sampler.params.ConsiderPrior = considerPrior
@@ -93,3 +94,33 @@ func SamplerOptionParzenEstimatorParams(params ParzenEstimatorParams) SamplerOpt
 		sampler.params = params
 	}
 }
+
+// SamplerOptionMultivariate enables the multivariate TPE, which samples the parameters
+// in the relative search space jointly to capture the dependencies between them.
+// The sampler must be also set by goptuna.StudyOptionRelativeSampler to use it.
+func SamplerOptionMultivariate(multivariate bool) SamplerOption {
+	return func(sampler *Sampler) {
+		sampler.multivariate = multivariate
+	}
+}
+
+// SamplerOptionGroup decomposes the search space into the groups of the parameters
+// which always appear together in the trials, then samples each group by the
+// multivariate TPE. This is useful for the conditional search spaces, whose
+// intersection search space contains only the shared parameters.
+// This option is effective only with SamplerOptionMultivariate(true).
+func SamplerOptionGroup(group bool) SamplerOption {
+	return func(sampler *Sampler) {
+		sampler.group = group
+	}
+}
+
+// SamplerOptionConstantLiar enables the constant liar heuristic for the parallel
+// optimization. The running trials which have the parameters are regarded as the
+// observations with the worst values, so that the workers avoid proposing the
+// parameters close to the ones being evaluated by the other workers.
+func SamplerOptionConstantLiar(constantLiar bool) SamplerOption {
+	return func(sampler *Sampler) {
+		sampler.constantLiar = constantLiar
+	}
+}
@@ -211,6 +211,48 @@ func TestSampler_SampleDiscreteUniform(t *testing.T) {
 	}
 }
 
+func TestSampler_SampleIntDistribution(t *testing.T) {
+	sampler := tpe.NewSampler(tpe.SamplerOptionNumberOfStartupTrials(0))
+	study, err := goptuna.CreateStudy("", goptuna.StudyOptionSampler(sampler))
+	if err != nil {
+		t.Errorf("should not be err, but got %s", err)
+		return
+	}
+
+	distributions := []goptuna.IntDistribution{
+		{Low: 1, High: 1000, Step: 1, Log: true},
+		{Low: -10, High: 11, Step: 3},
+	}
+	for _, distribution := range distributions {
+		for i := 0; i < 100; i++ {
+			trialID, err := study.Storage.CreateNewTrial(study.ID)
+			if err != nil {
+				t.Errorf("should not be err, but got %s", err)
+				return
+			}
+			trial, err := study.Storage.GetTrial(trialID)
+			if err != nil {
+				t.Errorf("should not be err, but got %s", err)
+				return
+			}
+			sampled, err := study.Sampler.Sample(study, trial, "x", distribution)
+			if err != nil {
+				t.Errorf("should not be err, but got %s", err)
+				return
+			}
+			if sampled < float64(distribution.Low) || sampled > float64(distribution.High) {
+				t.Errorf("should not be less than %d, and larger than %d, but got %f",
+					distribution.Low, distribution.High, sampled)
+				return
+			}
+			if int(sampled-float64(distribution.Low))%distribution.Step != 0 || sampled != math.Round(sampled) {
+				t.Errorf("should be on the grid, but got %f", sampled)
+				return
+			}
+		}
+	}
+}
+
 func TestGetObservationPairs_MINIMIZE(t *testing.T) {
 	study, err := goptuna.CreateStudy(
 		"", goptuna.StudyOptionIgnoreError(true),
@@ -239,7 +281,7 @@ func TestGetObservationPairs_MINIMIZE(t *testing.T) {
 		return
 	}
 
-	values, scores, err := tpe.ExportGetObservationPairs(study, "x")
+	values, scores, err := tpe.ExportGetObservationPairs(study, "x", false)
 	if err != nil {
 		t.Errorf("should be nil, but got %s", err)
 	}
@@ -287,7 +329,7 @@ func TestGetObservationPairs_MAXIMIZE(t *testing.T) {
 		return
 	}
 
-	values, scores, err := tpe.ExportGetObservationPairs(study, "x")
+	values, scores, err := tpe.ExportGetObservationPairs(study, "x", false)
 	if err != nil {
 		t.Errorf("should be nil, but got %s", err)
 	}
@@ -306,6 +348,60 @@ func TestGetObservationPairs_MAXIMIZE(t *testing.T) {
 	}
 }
 
+func TestGetObservationPairs_ConstantLiar(t *testing.T) {
+	study, err := goptuna.CreateStudy("", goptuna.StudyOptionLogger(nil))
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	err = study.Optimize(func(trial goptuna.Trial) (float64, error) {
+		x, _ := trial.SuggestFloat("x", 0, 10)
+		return x, nil
+	}, 1)
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	// The running trial which has the parameter.
+	trialID, err := study.Storage.CreateNewTrial(study.ID)
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	err = study.Storage.SetTrialParam(trialID, "x", 3, goptuna.UniformDistribution{Low: 0, High: 10})
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	// The running trial which doesn't have the parameter yet.
+	if _, err = study.Storage.CreateNewTrial(study.ID); err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+
+	values, _, err := tpe.ExportGetObservationPairs(study, "x", false)
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	if len(values) != 1 {
+		t.Errorf("running trials should be ignored, but got %v", values)
+	}
+
+	values, scores, err := tpe.ExportGetObservationPairs(study, "x", true)
+	if err != nil {
+		t.Errorf("should be nil, but got %s", err)
+		return
+	}
+	if len(values) != 2 || values[1] != 3 {
+		t.Errorf("should contain the running trial, but got %v", values)
+		return
+	}
+	if expected := [2]float64{math.Inf(1), math.Inf(1)}; scores[1] != expected {
+		t.Errorf("should be %v, but got %v", expected, scores[1])
+	}
+}
+
 // Following test cases are generated from Optuna's behavior.
 
 func TestSampler_splitObservationPairs(t *testing.T) {
@@ -373,7 +469,7 @@ func TestSampler_splitObservationPairs(t *testing.T) {
 
 func TestSampler_SampleCategorical(t *testing.T) {
 	d := goptuna.CategoricalDistribution{
-		Choices: []string{"a", "b", "c", "d"},
+		Choices: []interface{}{"a", "b", "c", "d"},
 	}
 	below := []float64{1.0}
 	above := []float64{1.0, 3.0, 3.0, 2.0, 3.0, 0.0, 2.0, 3.0, 3.0}
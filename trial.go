@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 )
 
 //go:generate stringer -trimprefix TrialState -output stringer_trial_state.go -type=TrialState
@@ -31,6 +32,36 @@ func (i TrialState) IsFinished() bool {
 	return i != TrialStateRunning && i != TrialStateWaiting
 }
 
+// BaseTrial is an interface implemented by Trial and FixedTrial.
+// Accepting BaseTrial in your objective function makes it testable with FixedTrial.
+type BaseTrial interface {
+	Number() (int, error)
+	SuggestUniform(name string, low, high float64) (float64, error)
+	SuggestLogUniform(name string, low, high float64) (float64, error)
+	SuggestDiscreteUniform(name string, low, high, q float64) (float64, error)
+	SuggestFloat(name string, low, high float64, opts ...SuggestFloatOption) (float64, error)
+	SuggestLogFloat(name string, low, high float64) (float64, error)
+	SuggestDiscreteFloat(name string, low, high, q float64) (float64, error)
+	SuggestInt(name string, low, high int, opts ...SuggestIntOption) (int, error)
+	SuggestStepInt(name string, low, high, step int) (int, error)
+	SuggestCategorical(name string, choices []string) (string, error)
+	SuggestCategoricalInt(name string, choices []int) (int, error)
+	SuggestCategoricalFloat(name string, choices []float64) (float64, error)
+	SuggestCategoricalBool(name string, choices []bool) (bool, error)
+	SuggestCategoricalAny(name string, choices []interface{}) (interface{}, error)
+	Suggest(name string, distribution Distribution) (interface{}, error)
+	Report(step int, value float64) error
+	ShouldPrune(step int, value float64) error
+	ShouldPruneLatest() error
+	SetUserAttr(key, value string) error
+	SetSystemAttr(key, value string) error
+	GetUserAttrs() (map[string]string, error)
+	GetSystemAttrs() (map[string]string, error)
+	GetContext() context.Context
+}
+
+var _ BaseTrial = &Trial{}
+
 // Trial is a process of evaluating an objective function.
 //
 // This object is passed to an objective function and provides interfaces to get parameter
@@ -44,6 +75,7 @@ type Trial struct {
 	value               float64
 	relativeParams      map[string]float64
 	relativeSearchSpace map[string]interface{}
+	ctx                 context.Context
 }
 
 func (t *Trial) isFixedParam(name string, distribution interface{}) (float64, bool, error) {
@@ -51,50 +83,52 @@ func (t *Trial) isFixedParam(name string, distribution interface{}) (float64, bo
 	if err != nil {
 		return 0, false, err
 	}
-	fixedParamsJSON, ok := systemAttrs["fixed_params"]
+
+	var internalParam float64
+	if fixedParamsJSON, ok := systemAttrs[fixedParamsSystemAttrKey]; ok {
+		var fixedParams map[string]float64
+		err = json.Unmarshal([]byte(fixedParamsJSON), &fixedParams)
+		if err != nil {
+			return 0, false, err
+		}
+		internalParam, ok = fixedParams[name]
+		if ok {
+			return t.containsFixedParam(distribution, internalParam)
+		}
+	}
+
+	fixedParamsJSON, ok := systemAttrs[fixedExternalParamsSystemAttrKey]
 	if !ok {
 		return 0, false, nil
 	}
-
-	var fixedParams map[string]float64
-	err = json.Unmarshal([]byte(fixedParamsJSON), &fixedParams)
-	if err != nil {
+	decoder := json.NewDecoder(strings.NewReader(fixedParamsJSON))
+	decoder.UseNumber()
+	var fixedParams map[string]interface{}
+	if err = decoder.Decode(&fixedParams); err != nil {
 		return 0, false, err
 	}
-
-	internalParam, ok := fixedParams[name]
+	externalParam, ok := fixedParams[name]
 	if !ok {
 		return 0, false, nil
 	}
+	internalParam, err = jsonValueToInternalRepr(distribution, externalParam)
+	if err != nil {
+		t.Study.logger.Warn("Enqueued parameter is ignored",
+			fmt.Sprintf("param=%s", name),
+			fmt.Sprintf("err=%s", err))
+		return 0, false, nil
+	}
+	return t.containsFixedParam(distribution, internalParam)
+}
 
-	switch typedDistribution := distribution.(type) {
-	case UniformDistribution:
-		if !typedDistribution.Contains(internalParam) {
-			return 0, false, nil
-		}
-	case LogUniformDistribution:
-		if !typedDistribution.Contains(internalParam) {
-			return 0, false, nil
-		}
-	case DiscreteUniformDistribution:
-		if !typedDistribution.Contains(internalParam) {
-			return 0, false, nil
-		}
-	case IntUniformDistribution:
-		if !typedDistribution.Contains(internalParam) {
-			return 0, false, nil
-		}
-	case StepIntUniformDistribution:
-		if !typedDistribution.Contains(internalParam) {
-			return 0, false, nil
-		}
-	case CategoricalDistribution:
-		if !typedDistribution.Contains(internalParam) {
-			return 0, false, nil
-		}
-	default:
+func (t *Trial) containsFixedParam(distribution interface{}, internalParam float64) (float64, bool, error) {
+	d, ok := distribution.(Distribution)
+	if !ok {
 		return 0, false, errors.New("unsupported distribution")
 	}
+	if !d.Contains(internalParam) {
+		return 0, false, nil
+	}
 	return internalParam, true, nil
 }
 
@@ -106,10 +140,19 @@ func (t *Trial) CallRelativeSampler() error {
 		return nil
 	}
 
-	var err error
+	frozen, err := t.Study.Storage.GetTrial(t.ID)
+	if err != nil {
+		return err
+	}
+
 	var searchSpace map[string]interface{}
 	if t.Study.definedSearchSpace != nil {
 		searchSpace = t.Study.definedSearchSpace
+	} else if inferrer, ok := t.Study.RelativeSampler.(RelativeSearchSpaceInferrer); ok {
+		searchSpace, err = inferrer.InferRelativeSearchSpace(t.Study, frozen)
+		if err != nil {
+			return err
+		}
 	} else {
 		searchSpace, err = IntersectionSearchSpace(t.Study)
 		if err != nil {
@@ -129,11 +172,6 @@ func (t *Trial) CallRelativeSampler() error {
 		relativeSearchSpace[paramName] = distribution
 	}
 
-	frozen, err := t.Study.Storage.GetTrial(t.ID)
-	if err != nil {
-		return err
-	}
-
 	relativeParams, err := t.Study.RelativeSampler.SampleRelative(t.Study, frozen, searchSpace)
 	if err == ErrUnsupportedSearchSpace {
 		t.Study.logger.Warn("Your objective function contains unsupported search space for RelativeSampler.",
@@ -157,12 +195,37 @@ func (t *Trial) isRelativeParam(name string, distribution interface{}) bool {
 	return reflect.DeepEqual(expected, distribution)
 }
 
+// checkDistribution checks the distribution is compatible with the latest one
+// of the same parameter name in the study.
+func (t *Trial) checkDistribution(name string, distribution interface{}, trialNumber int) error {
+	recorded, ok, err := t.Study.latestDistribution(name)
+	if err != nil {
+		return err
+	}
+	if ok {
+		if err = CheckDistributionCompatibility(recorded.distribution, distribution); err != nil {
+			return fmt.Errorf("parameter '%s': %w", name, err)
+		}
+		if distributionRangeIsChanged(recorded.distribution, distribution) {
+			t.Study.logger.Warn("The range of the parameter is changed from the previous trial",
+				fmt.Sprintf("param=%s", name),
+				fmt.Sprintf("trialNumber=%d", recorded.trialNumber))
+		}
+	}
+	t.Study.recordDistribution(name, distribution, trialNumber)
+	return nil
+}
+
 func (t *Trial) suggest(name string, distribution interface{}) (float64, error) {
 	trial, err := t.Study.Storage.GetTrial(t.ID)
 	if err != nil {
 		return 0.0, err
 	}
 
+	if err = t.checkDistribution(name, distribution, trial.Number); err != nil {
+		return 0.0, err
+	}
+
 	if value, ok, err := t.isFixedParam(name, distribution); err != nil {
 		return 0.0, err
 	} else if ok {
@@ -189,22 +252,43 @@ func (t *Trial) suggest(name string, distribution interface{}) (float64, error)
 	return v, err
 }
 
-// ShouldPrune judges whether the trial should be pruned.
-// This method calls prune method of the pruner, which judges whether
-// the trial should be pruned at the given step.
+// Report stores the intermediate value of the objective function at the given step.
+// Unlike ShouldPrune, this method doesn't consult the pruner, so it can be used
+// to record the learning curves of the studies without any pruner.
+func (t *Trial) Report(step int, value float64) error {
+	if t.Study.IsMultiObjective() {
+		return ErrMultiObjectiveStudy
+	}
+	if step < 0 {
+		return errors.New("step should be larger equal than 0")
+	}
+	return t.Study.Storage.SetTrialIntermediateValue(t.ID, step, value)
+}
+
+// ShouldPrune reports the intermediate value at the given step, then judges
+// whether the trial should be pruned. This is a shorthand of Report and ShouldPruneLatest.
 // If it should be pruned, this method return ErrTrialPruned.
 func (t *Trial) ShouldPrune(step int, value float64) error {
+	if err := t.Report(step, value); err != nil {
+		return err
+	}
 	if t.Study.Pruner == nil {
 		t.Study.logger.Warn("Although it's not registered pruner, but you calls ShouldPrune method")
 		return nil
 	}
+	return t.ShouldPruneLatest()
+}
 
-	if step < 0 {
-		return errors.New("step should be larger equal than 0")
+// ShouldPruneLatest judges whether the trial should be pruned at the latest step
+// reported by Report. This method calls prune method of the pruner.
+// If it should be pruned, this method return ErrTrialPruned.
+// It always returns nil if the pruner is not registered.
+func (t *Trial) ShouldPruneLatest() error {
+	if t.Study.IsMultiObjective() {
+		return ErrMultiObjectiveStudy
 	}
-
-	if err := t.Study.Storage.SetTrialIntermediateValue(t.ID, step, value); err != nil {
-		return err
+	if t.Study.Pruner == nil {
+		return nil
 	}
 
 	trial, err := t.Study.Storage.GetTrial(t.ID)
@@ -244,76 +328,64 @@ func (t *Trial) SuggestDiscreteUniform(name string, low, high, q float64) (float
 }
 
 // SuggestFloat suggests a value for the floating point parameter.
-func (t *Trial) SuggestFloat(name string, low, high float64) (float64, error) {
-	if low > high {
-		return 0, errors.New("'low' must be smaller than or equal to the 'high'")
-	}
-	return t.suggest(name, UniformDistribution{
-		High: high, Low: low,
-	})
+// If any option is given, the parameter is suggested from FloatDistribution.
+// Otherwise UniformDistribution is used for the compatibility with the existing studies.
+func (t *Trial) SuggestFloat(name string, low, high float64, opts ...SuggestFloatOption) (float64, error) {
+	return suggestFloat(t, name, low, high, opts...)
 }
 
 // SuggestLogFloat suggests a value for the log-scale floating point parameter.
 func (t *Trial) SuggestLogFloat(name string, low, high float64) (float64, error) {
-	if low > high {
-		return 0, errors.New("'low' must be smaller than or equal to the 'high'")
-	}
-	return t.suggest(name, LogUniformDistribution{
-		High: high, Low: low,
-	})
+	return suggestLogFloat(t, name, low, high)
 }
 
 // SuggestDiscreteFloat suggests a value for the discrete floating point parameter.
 func (t *Trial) SuggestDiscreteFloat(name string, low, high, q float64) (float64, error) {
-	if low > high {
-		return 0, errors.New("'low' must be smaller than or equal to the 'high'")
-	}
-	d := DiscreteUniformDistribution{
-		High: high, Low: low, Q: q,
-	}
-	ir, err := t.suggest(name, d)
-	if err != nil {
-		return 0, err
-	}
-	return d.ToExternalRepr(ir).(float64), err
+	return suggestDiscreteFloat(t, name, low, high, q)
 }
 
 // SuggestInt suggests an integer parameter.
-func (t *Trial) SuggestInt(name string, low, high int) (int, error) {
-	if low > high {
-		return 0, errors.New("'low' must be smaller than or equal to the 'high'")
-	}
-	d := IntUniformDistribution{
-		High: high, Low: low,
-	}
-	v, err := t.suggest(name, d)
-	return d.ToExternalRepr(v).(int), err
+// If any option is given, the parameter is suggested from IntDistribution.
+// Otherwise IntUniformDistribution is used for the compatibility with the existing studies.
+func (t *Trial) SuggestInt(name string, low, high int, opts ...SuggestIntOption) (int, error) {
+	return suggestInt(t, name, low, high, opts...)
 }
 
 // SuggestStepInt suggests a step-interval integer parameter.
 func (t *Trial) SuggestStepInt(name string, low, high, step int) (int, error) {
-	if low > high {
-		return 0, errors.New("'low' must be smaller than or equal to the 'high'")
-	}
-	if step <= 0 {
-		return 0, errors.New("'step' must be larger than 0")
-	}
-	d := StepIntUniformDistribution{
-		High: high, Low: low, Step: step,
-	}
-	v, err := t.suggest(name, d)
-	return d.ToExternalRepr(v).(int), err
+	return suggestStepInt(t, name, low, high, step)
 }
 
 // SuggestCategorical suggests an categorical parameter.
 func (t *Trial) SuggestCategorical(name string, choices []string) (string, error) {
-	if len(choices) == 0 {
-		return "", errors.New("'choices' must contains one or more elements")
-	}
-	v, err := t.suggest(name, CategoricalDistribution{
-		Choices: choices,
-	})
-	return choices[int(v)], err
+	return suggestCategorical(t, name, choices)
+}
+
+// SuggestCategoricalInt suggests an categorical parameter from int choices.
+func (t *Trial) SuggestCategoricalInt(name string, choices []int) (int, error) {
+	return suggestCategorical(t, name, choices)
+}
+
+// SuggestCategoricalFloat suggests an categorical parameter from float64 choices.
+func (t *Trial) SuggestCategoricalFloat(name string, choices []float64) (float64, error) {
+	return suggestCategorical(t, name, choices)
+}
+
+// SuggestCategoricalBool suggests an categorical parameter from bool choices.
+func (t *Trial) SuggestCategoricalBool(name string, choices []bool) (bool, error) {
+	return suggestCategorical(t, name, choices)
+}
+
+// SuggestCategoricalAny suggests an categorical parameter from mixed choices.
+// Each choice must be nil, bool, int, float64 or string.
+func (t *Trial) SuggestCategoricalAny(name string, choices []interface{}) (interface{}, error) {
+	return suggestCategoricalAny(t, name, choices)
+}
+
+// Suggest suggests a parameter from the given distribution, and returns
+// its external representation. This is useful for user-defined distributions.
+func (t *Trial) Suggest(name string, distribution Distribution) (interface{}, error) {
+	return suggestDistribution(t, name, distribution)
 }
 
 // SetUserAttr to store the value for the user.
@@ -337,6 +409,10 @@ func (t *Trial) GetSystemAttrs() (map[string]string, error) {
 }
 
 // GetContext returns a context which is registered at 'study.WithContext()'.
+// If the optimization has a timeout, the returned context is canceled at the timeout.
 func (t *Trial) GetContext() context.Context {
+	if t.ctx != nil {
+		return t.ctx
+	}
 	return t.Study.ctx
 }
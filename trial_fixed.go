@@ -0,0 +1,225 @@
+package goptuna
+
+import (
+	"context"
+	"fmt"
+)
+
+var _ BaseTrial = &FixedTrial{}
+
+// FixedTrial is a BaseTrial which suggests the fixed parameters.
+// This is useful to test your objective function without Study and Storage.
+// The suggested parameters, the intermediate values and the attributes are
+// recorded for assertions.
+type FixedTrial struct {
+	params             map[string]interface{}
+	suggestedParams    map[string]interface{}
+	distributions      map[string]interface{}
+	intermediateValues map[int]float64
+	userAttrs          map[string]string
+	systemAttrs        map[string]string
+	ctx                context.Context
+}
+
+// NewFixedTrial returns a new FixedTrial which suggests the given parameters.
+// The parameters are external representations, e.g. int for SuggestInt and
+// one of the choices for SuggestCategorical.
+func NewFixedTrial(params map[string]interface{}) *FixedTrial {
+	p := make(map[string]interface{}, len(params))
+	for name := range params {
+		p[name] = params[name]
+	}
+	return &FixedTrial{
+		params:             p,
+		suggestedParams:    make(map[string]interface{}, len(params)),
+		distributions:      make(map[string]interface{}, len(params)),
+		intermediateValues: make(map[int]float64),
+		userAttrs:          make(map[string]string),
+		systemAttrs:        make(map[string]string),
+		ctx:                context.Background(),
+	}
+}
+
+func (t *FixedTrial) suggest(name string, distribution interface{}) (float64, error) {
+	d, ok := distribution.(Distribution)
+	if !ok {
+		return 0, ErrUnknownDistribution
+	}
+	value, ok := t.params[name]
+	if !ok {
+		return 0, fmt.Errorf("parameter '%s' is not given to the FixedTrial", name)
+	}
+	if recorded, ok := t.distributions[name]; ok {
+		if err := CheckDistributionCompatibility(recorded, distribution); err != nil {
+			return 0, fmt.Errorf("parameter '%s': %w", name, err)
+		}
+	}
+
+	ir, err := toInternalRepr(distribution, value)
+	if err != nil {
+		return 0, fmt.Errorf("parameter '%s': %s", name, err)
+	}
+	if !d.Contains(ir) {
+		return 0, fmt.Errorf("parameter '%s': %#v is out of the range of %#v", name, value, distribution)
+	}
+	t.suggestedParams[name] = value
+	t.distributions[name] = distribution
+	return ir, nil
+}
+
+// Number always returns 0.
+func (t *FixedTrial) Number() (int, error) {
+	return 0, nil
+}
+
+// SuggestUniform returns the fixed value of the parameter.
+// Deprecated: This method will be removed at v1.0.0. Please use SuggestFloat method.
+func (t *FixedTrial) SuggestUniform(name string, low, high float64) (float64, error) {
+	return t.SuggestFloat(name, low, high)
+}
+
+// SuggestLogUniform returns the fixed value of the parameter.
+// Deprecated: This method will be removed at v1.0.0. Please use SuggestLogFloat method.
+func (t *FixedTrial) SuggestLogUniform(name string, low, high float64) (float64, error) {
+	return t.SuggestLogFloat(name, low, high)
+}
+
+// SuggestDiscreteUniform returns the fixed value of the parameter.
+// Deprecated: This method will be removed at v1.0.0. Please use SuggestDiscreteFloat method.
+func (t *FixedTrial) SuggestDiscreteUniform(name string, low, high, q float64) (float64, error) {
+	return t.SuggestDiscreteFloat(name, low, high, q)
+}
+
+// SuggestFloat returns the fixed value of the floating point parameter.
+func (t *FixedTrial) SuggestFloat(name string, low, high float64, opts ...SuggestFloatOption) (float64, error) {
+	return suggestFloat(t, name, low, high, opts...)
+}
+
+// SuggestLogFloat returns the fixed value of the log-scale floating point parameter.
+func (t *FixedTrial) SuggestLogFloat(name string, low, high float64) (float64, error) {
+	return suggestLogFloat(t, name, low, high)
+}
+
+// SuggestDiscreteFloat returns the fixed value of the discrete floating point parameter.
+func (t *FixedTrial) SuggestDiscreteFloat(name string, low, high, q float64) (float64, error) {
+	return suggestDiscreteFloat(t, name, low, high, q)
+}
+
+// SuggestInt returns the fixed value of the integer parameter.
+func (t *FixedTrial) SuggestInt(name string, low, high int, opts ...SuggestIntOption) (int, error) {
+	return suggestInt(t, name, low, high, opts...)
+}
+
+// SuggestStepInt returns the fixed value of the step-interval integer parameter.
+func (t *FixedTrial) SuggestStepInt(name string, low, high, step int) (int, error) {
+	return suggestStepInt(t, name, low, high, step)
+}
+
+// SuggestCategorical returns the fixed value of the categorical parameter.
+func (t *FixedTrial) SuggestCategorical(name string, choices []string) (string, error) {
+	return suggestCategorical(t, name, choices)
+}
+
+// SuggestCategoricalInt returns the fixed value of the categorical parameter from int choices.
+func (t *FixedTrial) SuggestCategoricalInt(name string, choices []int) (int, error) {
+	return suggestCategorical(t, name, choices)
+}
+
+// SuggestCategoricalFloat returns the fixed value of the categorical parameter from float64 choices.
+func (t *FixedTrial) SuggestCategoricalFloat(name string, choices []float64) (float64, error) {
+	return suggestCategorical(t, name, choices)
+}
+
+// SuggestCategoricalBool returns the fixed value of the categorical parameter from bool choices.
+func (t *FixedTrial) SuggestCategoricalBool(name string, choices []bool) (bool, error) {
+	return suggestCategorical(t, name, choices)
+}
+
+// SuggestCategoricalAny returns the fixed value of the categorical parameter from mixed choices.
+func (t *FixedTrial) SuggestCategoricalAny(name string, choices []interface{}) (interface{}, error) {
+	return suggestCategoricalAny(t, name, choices)
+}
+
+// Suggest returns the fixed value of the parameter from the given distribution.
+// The fixed value must be a float64 internal representation for user-defined distributions.
+func (t *FixedTrial) Suggest(name string, distribution Distribution) (interface{}, error) {
+	return suggestDistribution(t, name, distribution)
+}
+
+// Report records the intermediate value.
+func (t *FixedTrial) Report(step int, value float64) error {
+	t.intermediateValues[step] = value
+	return nil
+}
+
+// ShouldPrune records the intermediate value and never prunes the trial.
+func (t *FixedTrial) ShouldPrune(step int, value float64) error {
+	return t.Report(step, value)
+}
+
+// ShouldPruneLatest never prunes the trial.
+func (t *FixedTrial) ShouldPruneLatest() error {
+	return nil
+}
+
+// SetUserAttr records the value for the user.
+func (t *FixedTrial) SetUserAttr(key, value string) error {
+	t.userAttrs[key] = value
+	return nil
+}
+
+// SetSystemAttr records the value for the system.
+func (t *FixedTrial) SetSystemAttr(key, value string) error {
+	t.systemAttrs[key] = value
+	return nil
+}
+
+// GetUserAttrs returns the recorded user attributes.
+func (t *FixedTrial) GetUserAttrs() (map[string]string, error) {
+	attrs := make(map[string]string, len(t.userAttrs))
+	for k := range t.userAttrs {
+		attrs[k] = t.userAttrs[k]
+	}
+	return attrs, nil
+}
+
+// GetSystemAttrs returns the recorded system attributes.
+func (t *FixedTrial) GetSystemAttrs() (map[string]string, error) {
+	attrs := make(map[string]string, len(t.systemAttrs))
+	for k := range t.systemAttrs {
+		attrs[k] = t.systemAttrs[k]
+	}
+	return attrs, nil
+}
+
+// GetContext returns context.Background().
+func (t *FixedTrial) GetContext() context.Context {
+	return t.ctx
+}
+
+// Params returns the parameters which are suggested so far.
+func (t *FixedTrial) Params() map[string]interface{} {
+	params := make(map[string]interface{}, len(t.suggestedParams))
+	for name := range t.suggestedParams {
+		params[name] = t.suggestedParams[name]
+	}
+	return params
+}
+
+// Distributions returns the distributions of the parameters which are suggested so far.
+func (t *FixedTrial) Distributions() map[string]interface{} {
+	distributions := make(map[string]interface{}, len(t.distributions))
+	for name := range t.distributions {
+		distributions[name] = t.distributions[name]
+	}
+	return distributions
+}
+
+// IntermediateValues returns the intermediate values reported by Report or ShouldPrune.
+func (t *FixedTrial) IntermediateValues() map[int]float64 {
+	values := make(map[int]float64, len(t.intermediateValues))
+	for step := range t.intermediateValues {
+		values[step] = t.intermediateValues[step]
+	}
+	return values
+}
@@ -0,0 +1,122 @@
+package goptuna_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/c-bata/goptuna"
+)
+
+func objectiveForFixedTrial(trial goptuna.BaseTrial) (float64, error) {
+	x, err := trial.SuggestFloat("x", -10, 10)
+	if err != nil {
+		return 0, err
+	}
+	n, err := trial.SuggestInt("n", 1, 8, goptuna.SuggestIntOptionLog(true))
+	if err != nil {
+		return 0, err
+	}
+	optimizer, err := trial.SuggestCategorical("optimizer", []string{"adam", "sgd"})
+	if err != nil {
+		return 0, err
+	}
+	if err = trial.SetUserAttr("optimizer", optimizer); err != nil {
+		return 0, err
+	}
+	for step := 0; step < 3; step++ {
+		if err = trial.ShouldPrune(step, x*float64(step)); err != nil {
+			return 0, err
+		}
+	}
+	return x * float64(n), nil
+}
+
+func TestFixedTrial(t *testing.T) {
+	trial := goptuna.NewFixedTrial(map[string]interface{}{
+		"x":         2.0,
+		"n":         4,
+		"optimizer": "sgd",
+	})
+	value, err := objectiveForFixedTrial(trial)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	if value != 8 {
+		t.Errorf("should be 8, but got %f", value)
+	}
+
+	expectedParams := map[string]interface{}{"x": 2.0, "n": 4, "optimizer": "sgd"}
+	if !reflect.DeepEqual(trial.Params(), expectedParams) {
+		t.Errorf("should be %v, but got %v", expectedParams, trial.Params())
+	}
+	if _, ok := trial.Distributions()["n"].(goptuna.IntDistribution); !ok {
+		t.Errorf("should be IntDistribution, but got %#v", trial.Distributions()["n"])
+	}
+	expectedValues := map[int]float64{0: 0, 1: 2, 2: 4}
+	if !reflect.DeepEqual(trial.IntermediateValues(), expectedValues) {
+		t.Errorf("should be %v, but got %v", expectedValues, trial.IntermediateValues())
+	}
+	attrs, _ := trial.GetUserAttrs()
+	if attrs["optimizer"] != "sgd" {
+		t.Errorf("should be 'sgd', but got %#v", attrs)
+	}
+}
+
+func TestFixedTrial_InvalidParams(t *testing.T) {
+	tests := []struct {
+		name   string
+		params map[string]interface{}
+	}{
+		{
+			name:   "missing parameter",
+			params: map[string]interface{}{"x": 2.0, "n": 4},
+		},
+		{
+			name:   "out of range",
+			params: map[string]interface{}{"x": 20.0, "n": 4, "optimizer": "sgd"},
+		},
+		{
+			name:   "int parameter is given as float64",
+			params: map[string]interface{}{"x": 2.0, "n": 4.0, "optimizer": "sgd"},
+		},
+		{
+			name:   "not in choices",
+			params: map[string]interface{}{"x": 2.0, "n": 4, "optimizer": "rmsprop"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := objectiveForFixedTrial(goptuna.NewFixedTrial(tt.params))
+			if err == nil {
+				t.Errorf("should return an error")
+			}
+		})
+	}
+}
+
+func ExampleFixedTrial() {
+	objective := func(trial goptuna.BaseTrial) (float64, error) {
+		x, err := trial.SuggestFloat("x", -10, 10)
+		if err != nil {
+			return 0, err
+		}
+		return (x - 2) * (x - 2), nil
+	}
+
+	// Test the objective function with the fixed parameters.
+	value, err := objective(goptuna.NewFixedTrial(map[string]interface{}{"x": 5.0}))
+	fmt.Println(value, err)
+
+	// Optimize it by passing *goptuna.Trial.
+	study, _ := goptuna.CreateStudy("example", goptuna.StudyOptionLogger(nil))
+	err = study.Optimize(func(trial goptuna.Trial) (float64, error) {
+		return objective(&trial)
+	}, 10)
+	fmt.Println(err)
+	// Output:
+	// 9 <nil>
+	// <nil>
+}
@@ -13,6 +13,7 @@ type FrozenTrial struct {
 	Number             int                    `json:"number"`
 	State              TrialState             `json:"state"`
 	Value              float64                `json:"value"`
+	Values             []float64              `json:"values"`
 	IntermediateValues map[int]float64        `json:"intermediate_values"`
 	DatetimeStart      time.Time              `json:"datetime_start"`
 	DatetimeComplete   time.Time              `json:"datetime_complete"`
@@ -23,6 +24,54 @@ type FrozenTrial struct {
 	SystemAttrs        map[string]string      `json:"system_attrs"`
 }
 
+// NewCompletedTrial returns a completed trial to add it to the study by Study.AddTrial.
+// The params are external representations, e.g. int for IntDistribution and
+// one of the choices for CategoricalDistribution.
+func NewCompletedTrial(
+	params map[string]interface{},
+	distributions map[string]interface{},
+	value float64,
+) (FrozenTrial, error) {
+	if len(params) != len(distributions) {
+		return FrozenTrial{}, errors.New("`params` and `distributions` should be the same length")
+	}
+	internalParams := make(map[string]float64, len(params))
+	externalParams := make(map[string]interface{}, len(params))
+	for name := range params {
+		d, ok := distributions[name]
+		if !ok {
+			return FrozenTrial{}, fmt.Errorf("distribution '%s' is not found", name)
+		}
+		ir, err := toInternalRepr(d, params[name])
+		if err != nil {
+			return FrozenTrial{}, fmt.Errorf("parameter '%s': %s", name, err)
+		}
+		internalParams[name] = ir
+		// Normalize the type of the external representation, e.g. int to float64.
+		if externalParams[name], err = ToExternalRepresentation(d, ir); err != nil {
+			return FrozenTrial{}, err
+		}
+	}
+
+	now := time.Now()
+	trial := FrozenTrial{
+		ID:                 -1, // dummy value
+		Number:             -1, // dummy value
+		State:              TrialStateComplete,
+		Value:              value,
+		Values:             []float64{value},
+		IntermediateValues: make(map[int]float64),
+		DatetimeStart:      now,
+		DatetimeComplete:   now,
+		InternalParams:     internalParams,
+		Params:             externalParams,
+		Distributions:      copyMap(distributions),
+		UserAttrs:          make(map[string]string),
+		SystemAttrs:        make(map[string]string),
+	}
+	return trial, trial.validate()
+}
+
 // GetLatestStep returns the latest step in intermediate values.
 func (t FrozenTrial) GetLatestStep() (step int, exist bool) {
 	if len(t.IntermediateValues) == 0 {
@@ -69,34 +118,13 @@ func (t FrozenTrial) validate() error {
 		ir := t.InternalParams[name]
 		d := t.Distributions[name]
 
-		switch typedDistribution := d.(type) {
-		case UniformDistribution:
-			if !typedDistribution.Contains(ir) {
-				return fmt.Errorf("internal param is out of the distribution range")
-			}
-		case LogUniformDistribution:
-			if !typedDistribution.Contains(ir) {
-				return fmt.Errorf("internal param is out of the distribution range")
-			}
-		case IntUniformDistribution:
-			if !typedDistribution.Contains(ir) {
-				return fmt.Errorf("internal param is out of the distribution range")
-			}
-		case StepIntUniformDistribution:
-			if !typedDistribution.Contains(ir) {
-				return fmt.Errorf("internal param is out of the distribution range")
-			}
-		case DiscreteUniformDistribution:
-			if !typedDistribution.Contains(ir) {
-				return fmt.Errorf("internal param is out of the distribution range")
-			}
-		case CategoricalDistribution:
-			if !typedDistribution.Contains(ir) {
-				return fmt.Errorf("internal param is out of the distribution range")
-			}
-		default:
+		typedDistribution, ok := d.(Distribution)
+		if !ok {
 			return errors.New("unsupported distribution")
 		}
+		if !typedDistribution.Contains(ir) {
+			return fmt.Errorf("internal param is out of the distribution range")
+		}
 
 		expectedXr, err := ToExternalRepresentation(d, ir)
 		if err != nil {
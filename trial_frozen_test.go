@@ -177,3 +177,60 @@ func TestFrozenTrial_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestNewCompletedTrial(t *testing.T) {
+	distributions := map[string]interface{}{
+		"x":         goptuna.UniformDistribution{Low: -10, High: 10},
+		"optimizer": goptuna.CategoricalDistribution{Choices: []interface{}{"adam", "sgd"}},
+	}
+	tests := []struct {
+		name    string
+		params  map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			params:  map[string]interface{}{"x": 1.5, "optimizer": "sgd"},
+			wantErr: false,
+		},
+		{
+			name:    "int is given for the float parameter",
+			params:  map[string]interface{}{"x": 1, "optimizer": "sgd"},
+			wantErr: false,
+		},
+		{
+			name:    "out of range",
+			params:  map[string]interface{}{"x": 20.0, "optimizer": "sgd"},
+			wantErr: true,
+		},
+		{
+			name:    "not in choices",
+			params:  map[string]interface{}{"x": 1.5, "optimizer": "rmsprop"},
+			wantErr: true,
+		},
+		{
+			name:    "missing parameter",
+			params:  map[string]interface{}{"x": 1.5},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trial, err := goptuna.NewCompletedTrial(tt.params, distributions, 1.0)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewCompletedTrial() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if trial.State != goptuna.TrialStateComplete || trial.Value != 1.0 {
+				t.Errorf("unexpected trial: %#v", trial)
+			}
+			if trial.InternalParams["optimizer"] != 1 {
+				t.Errorf("internal param should be 1, but got %f", trial.InternalParams["optimizer"])
+			}
+		})
+	}
+}
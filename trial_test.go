@@ -1,6 +1,8 @@
 package goptuna_test
 
 import (
+	"errors"
+	"fmt"
 	"math"
 	"testing"
 
@@ -103,6 +105,58 @@ func TestTrial_Suggest(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "SuggestFloat with step and log options",
+			objective: func(trial goptuna.Trial) (float64, error) {
+				x1, err := trial.SuggestFloat("x", 1, 100,
+					goptuna.SuggestFloatOptionStep(0.5), goptuna.SuggestFloatOptionLog(true))
+				if err != nil {
+					return -1, err
+				}
+				if x1 < 1 || x1 > 100 || math.Mod(x1-1, 0.5) != 0 {
+					return -1, fmt.Errorf("unexpected value: %f", x1)
+				}
+				return math.Pow(x1-2, 2), nil
+			},
+			wantErr: false,
+		},
+		{
+			name: "SuggestFloat: 'low' must be larger than 0 for the log distribution",
+			objective: func(trial goptuna.Trial) (float64, error) {
+				x1, err := trial.SuggestFloat("x", 0, 100, goptuna.SuggestFloatOptionLog(true))
+				if err != nil {
+					return -1, err
+				}
+				return math.Pow(x1-2, 2), nil
+			},
+			wantErr: true,
+		},
+		{
+			name: "SuggestInt with step and log options",
+			objective: func(trial goptuna.Trial) (float64, error) {
+				x1, err := trial.SuggestInt("x", 1, 1025,
+					goptuna.SuggestIntOptionStep(4), goptuna.SuggestIntOptionLog(true))
+				if err != nil {
+					return -1, err
+				}
+				if x1 < 1 || x1 > 1025 || (x1-1)%4 != 0 {
+					return -1, fmt.Errorf("unexpected value: %d", x1)
+				}
+				return math.Pow(float64(x1-2), 2), nil
+			},
+			wantErr: false,
+		},
+		{
+			name: "SuggestInt: 'step' must be larger than 0",
+			objective: func(trial goptuna.Trial) (float64, error) {
+				x1, err := trial.SuggestInt("x", -10, 10, goptuna.SuggestIntOptionStep(0))
+				if err != nil {
+					return -1, err
+				}
+				return math.Pow(float64(x1-2), 2), nil
+			},
+			wantErr: true,
+		},
 		{
 			name: "SuggestCategorical",
 			objective: func(trial goptuna.Trial) (float64, error) {
@@ -117,6 +171,42 @@ func TestTrial_Suggest(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "SuggestCategoricalInt",
+			objective: func(trial goptuna.Trial) (float64, error) {
+				x1, err := trial.SuggestCategoricalInt("x", []int{16, 32, 64})
+				if err != nil {
+					return -1, err
+				}
+				return float64(x1), nil
+			},
+			wantErr: false,
+		},
+		{
+			name: "SuggestCategoricalAny",
+			objective: func(trial goptuna.Trial) (float64, error) {
+				x1, err := trial.SuggestCategoricalAny("x", []interface{}{nil, true, 1, 0.5, "foo"})
+				if err != nil {
+					return -1, err
+				}
+				if x1 == nil {
+					return 0, nil
+				}
+				return 1, nil
+			},
+			wantErr: false,
+		},
+		{
+			name: "SuggestCategoricalAny: unsupported choice",
+			objective: func(trial goptuna.Trial) (float64, error) {
+				_, err := trial.SuggestCategoricalAny("x", []interface{}{int64(1), struct{}{}})
+				if err != nil {
+					return -1, err
+				}
+				return 1, nil
+			},
+			wantErr: true,
+		},
 		{
 			name: "SuggestCategorical: 'choices' must contains one or more elements",
 			objective: func(trial goptuna.Trial) (float64, error) {
@@ -227,3 +317,230 @@ func TestTrial_SystemAttrs(t *testing.T) {
 		t.Errorf("should be 'world', but got '%s'", hello)
 	}
 }
+
+func TestTrial_SuggestIncompatibleDistribution(t *testing.T) {
+	tests := []struct {
+		name    string
+		first   func(trial goptuna.Trial) error
+		second  func(trial goptuna.Trial) error
+		wantErr bool
+	}{
+		{
+			name: "uniform and log uniform",
+			first: func(trial goptuna.Trial) error {
+				_, err := trial.SuggestFloat("lr", 0, 1)
+				return err
+			},
+			second: func(trial goptuna.Trial) error {
+				_, err := trial.SuggestLogFloat("lr", 1e-5, 1)
+				return err
+			},
+			wantErr: true,
+		},
+		{
+			name: "uniform and float distribution with different range",
+			first: func(trial goptuna.Trial) error {
+				_, err := trial.SuggestUniform("lr", 0, 1)
+				return err
+			},
+			second: func(trial goptuna.Trial) error {
+				_, err := trial.SuggestFloat("lr", 0, 0.5, goptuna.SuggestFloatOptionStep(0.1))
+				return err
+			},
+			wantErr: false,
+		},
+		{
+			name: "float and int",
+			first: func(trial goptuna.Trial) error {
+				_, err := trial.SuggestFloat("x", 0, 10)
+				return err
+			},
+			second: func(trial goptuna.Trial) error {
+				_, err := trial.SuggestInt("x", 0, 10)
+				return err
+			},
+			wantErr: true,
+		},
+		{
+			name: "categorical with different choices",
+			first: func(trial goptuna.Trial) error {
+				_, err := trial.SuggestCategorical("optimizer", []string{"adam", "sgd"})
+				return err
+			},
+			second: func(trial goptuna.Trial) error {
+				_, err := trial.SuggestCategorical("optimizer", []string{"adam", "rmsprop"})
+				return err
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			study, _ := goptuna.CreateStudy(
+				"example",
+				goptuna.StudyOptionStorage(goptuna.NewInMemoryStorage()),
+				goptuna.StudyOptionLogger(nil),
+			)
+			for i, suggest := range []func(trial goptuna.Trial) error{tt.first, tt.second} {
+				trialID, err := study.Storage.CreateNewTrial(study.ID)
+				if err != nil {
+					t.Errorf("err: %v != nil", err)
+					return
+				}
+				err = suggest(goptuna.Trial{Study: study, ID: trialID})
+				if i == 0 && err != nil {
+					t.Errorf("err: %v != nil", err)
+					return
+				}
+				if i == 1 && errors.Is(err, goptuna.ErrIncompatibleDistribution) != tt.wantErr {
+					t.Errorf("err: %v, wantErr %v", err, tt.wantErr)
+				}
+			}
+		})
+	}
+}
+
+type countingStorage struct {
+	goptuna.Storage
+	getAllTrialsCalls int
+}
+
+func (s *countingStorage) GetAllTrials(studyID int) ([]goptuna.FrozenTrial, error) {
+	s.getAllTrialsCalls++
+	return s.Storage.GetAllTrials(studyID)
+}
+
+func TestTrial_SuggestLoadsTrialsOncePerParam(t *testing.T) {
+	storage := &countingStorage{Storage: goptuna.NewInMemoryStorage()}
+	study, err := goptuna.CreateStudy(
+		"example",
+		goptuna.StudyOptionStorage(storage),
+		goptuna.StudyOptionLogger(nil),
+	)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	for i := 0; i < 10; i++ {
+		trialID, err := study.Storage.CreateNewTrial(study.ID)
+		if err != nil {
+			t.Errorf("err: %v != nil", err)
+			return
+		}
+		trial := goptuna.Trial{Study: study, ID: trialID}
+		if _, err = trial.SuggestFloat("x", -10, 10); err != nil {
+			t.Errorf("err: %v != nil", err)
+			return
+		}
+		if _, err = trial.SuggestInt("y", -10, 10); err != nil {
+			t.Errorf("err: %v != nil", err)
+			return
+		}
+	}
+	if storage.getAllTrialsCalls > 2 {
+		t.Errorf("all trials should be loaded at most once per parameter, but got %d calls",
+			storage.getAllTrialsCalls)
+	}
+
+	// Another study object checks the distributions recorded in the storage.
+	loaded, err := goptuna.LoadStudy(
+		"example",
+		goptuna.StudyOptionStorage(storage),
+		goptuna.StudyOptionLogger(nil),
+	)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	trialID, err := loaded.Storage.CreateNewTrial(loaded.ID)
+	if err != nil {
+		t.Errorf("err: %v != nil", err)
+		return
+	}
+	trial := goptuna.Trial{Study: loaded, ID: trialID}
+	_, err = trial.SuggestLogFloat("x", 1e-5, 1)
+	if !errors.Is(err, goptuna.ErrIncompatibleDistribution) {
+		t.Errorf("err: %v, want ErrIncompatibleDistribution", err)
+	}
+}
+
+type latestStepPruner struct {
+	pruneStep int
+}
+
+func (p latestStepPruner) Prune(study *goptuna.Study, trial goptuna.FrozenTrial) (bool, error) {
+	step, ok := trial.GetLatestStep()
+	return ok && step >= p.pruneStep, nil
+}
+
+func TestTrial_Report(t *testing.T) {
+	tests := []struct {
+		name       string
+		pruner     goptuna.Pruner
+		wantPruned bool
+	}{
+		{
+			name:       "without pruner",
+			pruner:     nil,
+			wantPruned: false,
+		},
+		{
+			name:       "with pruner",
+			pruner:     latestStepPruner{pruneStep: 20},
+			wantPruned: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			study, _ := goptuna.CreateStudy(
+				"example",
+				goptuna.StudyOptionStorage(goptuna.NewInMemoryStorage()),
+				goptuna.StudyOptionPruner(tt.pruner),
+				goptuna.StudyOptionLogger(nil),
+			)
+			trialID, err := study.Storage.CreateNewTrial(study.ID)
+			if err != nil {
+				t.Errorf("err: %v != nil", err)
+				return
+			}
+			trial := goptuna.Trial{Study: study, ID: trialID}
+
+			var pruned bool
+			for step := 0; step < 30; step++ {
+				if err = trial.Report(step, float64(step)); err != nil {
+					t.Errorf("err: %v != nil", err)
+					return
+				}
+				if step%10 != 0 {
+					continue
+				}
+				if err = trial.ShouldPruneLatest(); err == goptuna.ErrTrialPruned {
+					pruned = true
+					break
+				} else if err != nil {
+					t.Errorf("err: %v != nil", err)
+					return
+				}
+			}
+			if pruned != tt.wantPruned {
+				t.Errorf("pruned should be %v, but got %v", tt.wantPruned, pruned)
+			}
+
+			frozen, err := study.Storage.GetTrial(trialID)
+			if err != nil {
+				t.Errorf("err: %v != nil", err)
+				return
+			}
+			wantSteps := 30
+			if tt.wantPruned {
+				wantSteps = 21
+			}
+			if len(frozen.IntermediateValues) != wantSteps {
+				t.Errorf("should be %d intermediate values, but got %d",
+					wantSteps, len(frozen.IntermediateValues))
+			}
+		})
+	}
+}